@@ -0,0 +1,510 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ledzpl/pcl/internal/config"
+	gittool "github.com/ledzpl/pcl/internal/git"
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+// command는 프롬프트 없이 플래그만으로 실행되는 하위 명령이다.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, configPath string) error
+}
+
+var commands = []command{
+	{name: "commit", summary: "diff로 커밋 메시지를 생성합니다", run: runCommitCommand},
+	{name: "issue", summary: "diff로 Jira 이슈를 생성합니다", run: runIssueCommand},
+	{name: "update", summary: "새 diff를 반영해 기존 Jira 이슈의 설명을 갱신합니다", run: runUpdateCommand},
+	{name: "comment", summary: "마지막 댓글 이후의 커밋을 요약해 Jira 이슈에 댓글을 답니다", run: runCommentCommand},
+	{name: "transition", summary: "Jira 이슈에 적용할 수 있는 전환을 보여주거나 적용합니다", run: runTransitionCommand},
+	{name: "hook", summary: "prepare-commit-msg 훅을 설치(install)하거나 제거(uninstall)합니다", run: runHookCommand},
+}
+
+var errNoChanges = errors.New("비교할 변경점이 없습니다")
+
+func runCommand(args []string, configPath string) error {
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(args[1:], configPath)
+		}
+	}
+	return fmt.Errorf("알 수 없는 명령입니다: %s", args[0])
+}
+
+type commitOptions struct {
+	configPath  string
+	ai          aiFlags
+	filter      filterFlags
+	base        string
+	source      gittool.Source
+	apply       bool
+	yes         bool
+	messageFile string
+}
+
+func parseCommitFlags(args []string, configPath string, output io.Writer) (commitOptions, error) {
+	opts := commitOptions{}
+	var source string
+
+	fs := flag.NewFlagSet("commit", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&opts.configPath, "config", configPath, "path to configuration file")
+	fs.StringVar(&opts.base, "base", "", "base branch to diff against (required for worktree and committed sources)")
+	fs.StringVar(&source, "source", string(gittool.SourceStaged), "diff source: worktree, staged, unstaged or committed")
+	fs.BoolVar(&opts.apply, "apply", false, "commit the staged changes with the generated message")
+	fs.BoolVar(&opts.yes, "yes", false, "with -apply, commit without the review prompt")
+	fs.StringVar(&opts.messageFile, "message-file", "", "write the generated message to the start of this file (used by the git hook)")
+	opts.ai.register(fs)
+	opts.filter.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("commit: unexpected arguments: %v", fs.Args())
+	}
+	if opts.yes && !opts.apply {
+		return opts, errors.New("commit: -yes requires -apply")
+	}
+	if opts.apply && opts.messageFile != "" {
+		return opts, errors.New("commit: -apply and -message-file cannot be used together")
+	}
+
+	src, err := resolveSource("commit", source, opts.base)
+	if err != nil {
+		return opts, err
+	}
+	opts.source = src
+	// -apply는 인덱스만 커밋하므로 다른 범위의 diff로 만든 메시지는 커밋 내용과 맞지 않는다.
+	if opts.apply && opts.source != gittool.SourceStaged {
+		return opts, fmt.Errorf("commit: -apply requires -source %s, got %s", gittool.SourceStaged, opts.source)
+	}
+
+	return opts, nil
+}
+
+func runCommitCommand(args []string, configPath string) error {
+	opts, err := parseCommitFlags(args, configPath, flag.CommandLine.Output())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return err
+	}
+	opts.ai.apply(cfg, config.ActionCommit)
+	opts.filter.apply(cfg)
+
+	patch, err := loadPatch(cfg, opts.source, opts.base)
+	if err != nil {
+		return err
+	}
+
+	if opts.apply {
+		return commitChanges(cfg, patch, opts.yes)
+	}
+	if opts.messageFile != "" {
+		message, err := commitMessage(cfg, patch)
+		if err != nil {
+			return err
+		}
+		return prependToFile(opts.messageFile, message)
+	}
+	return generateCommitMessage(cfg, patch)
+}
+
+type issueOptions struct {
+	configPath string
+	ai         aiFlags
+	filter     filterFlags
+	base       string
+	source     gittool.Source
+	dryRun     bool
+	force      bool
+	review     bool
+	json       bool
+	parent     string
+	links      []jira.Link
+	fields     issueFieldOptions
+	split      bool
+	epic       bool
+}
+
+func parseIssueFlags(args []string, configPath string, output io.Writer) (issueOptions, error) {
+	opts := issueOptions{}
+	var source string
+	var links, labels, components, fixVersions stringList
+
+	fs := flag.NewFlagSet("issue", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&opts.configPath, "config", configPath, "path to configuration file")
+	fs.StringVar(&opts.base, "base", "", "base branch to diff against (required for worktree and committed sources)")
+	fs.StringVar(&source, "source", string(gittool.SourceWorkingTree), "diff source: worktree, staged, unstaged or committed")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "print the generated payload without creating the issue")
+	fs.BoolVar(&opts.review, "review", false, "preview the issue and approve, edit, regenerate or cancel before creating it")
+	fs.BoolVar(&opts.json, "json", false, "print the created issue (id, key, self, url) as JSON")
+	fs.BoolVar(&opts.force, "force", false, "create an issue even if the model considers the change trivial")
+	fs.StringVar(&opts.parent, "parent", "", "parent issue or epic key for the new issue (e.g. PCL-10), or auto to use the key found in the branch name or commits")
+	fs.Var(&links, "link", "link the new issue to an existing one as type:KEY, e.g. blocks:PCL-12 (repeatable; types: "+strings.Join(jira.LinkNames(), ", ")+")")
+	fs.Var(&labels, "label", "add this label to the new issue (repeatable; added to jira_labels)")
+	fs.Var(&components, "component", "add this component to the new issue (repeatable; added to jira_components)")
+	fs.Var(&fixVersions, "fix-version", "add this fix version to the new issue (repeatable; added to jira_fix_versions)")
+	fs.StringVar(&opts.fields.priority, "priority", "", "priority of the new issue, e.g. High (overrides the model and jira_priority)")
+	fs.BoolVar(&opts.fields.sprint, "sprint", false, "add the new issue to the active sprint")
+	fs.BoolVar(&opts.split, "split", false, "split the diff by intent into several linked issues (choose which to create with -review)")
+	fs.BoolVar(&opts.epic, "epic", false, "with -split, also create an epic and make the split issues its children")
+	opts.ai.register(fs)
+	opts.filter.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("issue: unexpected arguments: %v", fs.Args())
+	}
+	for _, l := range labels {
+		if l = strings.TrimSpace(l); l == "" || strings.ContainsAny(l, " \t") {
+			return opts, fmt.Errorf("issue: -label must not be empty or contain spaces, got %q", l)
+		}
+		opts.fields.labels = append(opts.fields.labels, l)
+	}
+	opts.fields.components = components
+	opts.fields.fixVersions = fixVersions
+	opts.fields.priority = strings.TrimSpace(opts.fields.priority)
+	opts.parent = strings.TrimSpace(opts.parent)
+	if strings.EqualFold(opts.parent, parentAuto) {
+		opts.parent = parentAuto
+	} else {
+		opts.parent = strings.ToUpper(opts.parent)
+	}
+	if opts.parent != "" && opts.parent != parentAuto && !jira.ValidIssueKey(opts.parent) {
+		return opts, fmt.Errorf("issue: -parent must be an issue key like PCL-10 or auto, got %q", opts.parent)
+	}
+	for _, l := range links {
+		link, err := jira.ParseLink(l)
+		if err != nil {
+			return opts, fmt.Errorf("issue: %w", err)
+		}
+		opts.links = append(opts.links, link)
+	}
+	if opts.epic && !opts.split {
+		return opts, errors.New("issue: -epic requires -split")
+	}
+	if opts.epic && opts.parent != "" {
+		return opts, errors.New("issue: -epic and -parent cannot be used together")
+	}
+
+	src, err := resolveSource("issue", source, opts.base)
+	if err != nil {
+		return opts, err
+	}
+	opts.source = src
+
+	return opts, nil
+}
+
+func runIssueCommand(args []string, configPath string) error {
+	opts, err := parseIssueFlags(args, configPath, flag.CommandLine.Output())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return err
+	}
+	opts.ai.apply(cfg, config.ActionIssue)
+	opts.filter.apply(cfg)
+
+	patch, err := loadPatch(cfg, opts.source, opts.base)
+	if err != nil {
+		return err
+	}
+
+	return createIssue(cfg, patch, issueFlow{
+		dryRun: opts.dryRun,
+		force:  opts.force,
+		review: opts.review,
+		json:   opts.json,
+		parent: opts.parent,
+		links:  opts.links,
+		base:   opts.base,
+		fields: opts.fields,
+		split:  opts.split,
+		epic:   opts.epic,
+	})
+}
+
+type updateOptions struct {
+	configPath string
+	ai         aiFlags
+	filter     filterFlags
+	base       string
+	source     gittool.Source
+	key        string
+	dryRun     bool
+	yes        bool
+	force      bool
+}
+
+func parseUpdateFlags(args []string, configPath string, output io.Writer) (updateOptions, error) {
+	opts := updateOptions{}
+	var source string
+
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&opts.configPath, "config", configPath, "path to configuration file")
+	fs.StringVar(&opts.base, "base", "", "base branch to diff against (required for worktree and committed sources)")
+	fs.StringVar(&source, "source", string(gittool.SourceWorkingTree), "diff source: worktree, staged, unstaged or committed")
+	fs.StringVar(&opts.key, "issue", "", "issue key to update (default: the key found in the branch name or commits)")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "show how the description would change without updating the issue")
+	fs.BoolVar(&opts.yes, "yes", false, "update without the confirmation prompt")
+	fs.BoolVar(&opts.force, "force", false, "update even if the current description has content pcl cannot keep (tables, mentions, media, ...)")
+	opts.ai.register(fs)
+	opts.filter.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("update: unexpected arguments: %v", fs.Args())
+	}
+	opts.key = strings.ToUpper(strings.TrimSpace(opts.key))
+	if opts.key != "" && !jira.ValidIssueKey(opts.key) {
+		return opts, fmt.Errorf("update: -issue must be an issue key like PCL-10, got %q", opts.key)
+	}
+
+	src, err := resolveSource("update", source, opts.base)
+	if err != nil {
+		return opts, err
+	}
+	opts.source = src
+
+	return opts, nil
+}
+
+func runUpdateCommand(args []string, configPath string) error {
+	opts, err := parseUpdateFlags(args, configPath, flag.CommandLine.Output())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return err
+	}
+	opts.ai.apply(cfg, config.ActionIssue)
+	opts.filter.apply(cfg)
+
+	patch, err := loadPatch(cfg, opts.source, opts.base)
+	if err != nil {
+		return err
+	}
+
+	return updateIssue(cfg, patch, updateFlow{key: opts.key, base: opts.base, dryRun: opts.dryRun, yes: opts.yes, force: opts.force})
+}
+
+type commentOptions struct {
+	configPath string
+	ai         aiFlags
+	filter     filterFlags
+	base       string
+	key        string
+	dryRun     bool
+	yes        bool
+}
+
+func parseCommentFlags(args []string, configPath string, output io.Writer) (commentOptions, error) {
+	opts := commentOptions{}
+
+	fs := flag.NewFlagSet("comment", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&opts.configPath, "config", configPath, "path to configuration file")
+	fs.StringVar(&opts.base, "base", "", "base branch to start from when the issue has no recorded comment")
+	fs.StringVar(&opts.key, "issue", "", "issue key to comment on (default: the key found in the branch name or commits)")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "print the generated comment without posting it")
+	fs.BoolVar(&opts.yes, "yes", false, "post without the confirmation prompt")
+	opts.ai.register(fs)
+	opts.filter.register(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("comment: unexpected arguments: %v", fs.Args())
+	}
+	opts.key = strings.ToUpper(strings.TrimSpace(opts.key))
+	if opts.key != "" && !jira.ValidIssueKey(opts.key) {
+		return opts, fmt.Errorf("comment: -issue must be an issue key like PCL-10, got %q", opts.key)
+	}
+	opts.base = strings.TrimSpace(opts.base)
+
+	return opts, nil
+}
+
+func runCommentCommand(args []string, configPath string) error {
+	opts, err := parseCommentFlags(args, configPath, flag.CommandLine.Output())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return err
+	}
+	opts.ai.apply(cfg, config.ActionIssue)
+	opts.filter.apply(cfg)
+
+	return commentProgress(cfg, commentFlow{key: opts.key, base: opts.base, dryRun: opts.dryRun, yes: opts.yes})
+}
+
+type transitionOptions struct {
+	configPath string
+	base       string
+	key        string
+	to         string
+	list       bool
+}
+
+func parseTransitionFlags(args []string, configPath string, output io.Writer) (transitionOptions, error) {
+	opts := transitionOptions{}
+
+	fs := flag.NewFlagSet("transition", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&opts.configPath, "config", configPath, "path to configuration file")
+	fs.StringVar(&opts.base, "base", "", "base branch whose commits are searched for the issue key")
+	fs.StringVar(&opts.key, "issue", "", "issue key to transition (default: the key found in the branch name or commits)")
+	fs.StringVar(&opts.to, "to", "", "transition or target status name to apply, e.g. \"In Review\" (default: choose from a list)")
+	fs.BoolVar(&opts.list, "list", false, "list the transitions available for the issue without applying one")
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("transition: unexpected arguments: %v", fs.Args())
+	}
+	opts.key = strings.ToUpper(strings.TrimSpace(opts.key))
+	if opts.key != "" && !jira.ValidIssueKey(opts.key) {
+		return opts, fmt.Errorf("transition: -issue must be an issue key like PCL-10, got %q", opts.key)
+	}
+	opts.to = strings.TrimSpace(opts.to)
+	if opts.list && opts.to != "" {
+		return opts, errors.New("transition: -list and -to cannot be used together")
+	}
+
+	return opts, nil
+}
+
+func runTransitionCommand(args []string, configPath string) error {
+	opts, err := parseTransitionFlags(args, configPath, flag.CommandLine.Output())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(opts.configPath)
+	if err != nil {
+		return err
+	}
+
+	return transitionIssue(cfg, transitionFlow{key: opts.key, base: opts.base, to: opts.to, list: opts.list})
+}
+
+// resolveSource는 -source 값을 검증하고, 기준 브랜치가 필요한 범위에서 -base 누락을 막는다.
+func resolveSource(name, value, base string) (gittool.Source, error) {
+	src, err := gittool.ParseSource(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	if src.NeedsBase() && IsBlank(base) {
+		return "", fmt.Errorf("%s: -base is required for the %s source", name, src)
+	}
+	return src, nil
+}
+
+// aiFlags는 설정 파일의 AI 생성 파라미터를 명령행에서 덮어쓰는 플래그 모음이다.
+type aiFlags struct {
+	model           string
+	baseURL         string
+	temperature     float64
+	reasoningEffort string
+	maxTokens       int
+	seed            int64
+	fs              *flag.FlagSet
+}
+
+func (a *aiFlags) register(fs *flag.FlagSet) {
+	a.fs = fs
+	fs.StringVar(&a.model, "model", "", "model name for this command (overrides ai_model)")
+	fs.StringVar(&a.baseURL, "base-url", "", "AI API base URL (overrides ai_base_url)")
+	fs.Float64Var(&a.temperature, "temperature", 0, "sampling temperature (overrides ai_temperature)")
+	fs.StringVar(&a.reasoningEffort, "reasoning-effort", "", "reasoning effort: minimal, low, medium or high")
+	fs.IntVar(&a.maxTokens, "max-tokens", 0, "maximum output tokens (overrides ai_max_tokens)")
+	fs.Int64Var(&a.seed, "seed", 0, "sampling seed (overrides ai_seed)")
+}
+
+// apply는 명령행에서 지정된 플래그만 cfg에 반영한다. 값 검증은 config.ValidateForAI가 맡는다.
+func (a *aiFlags) apply(cfg *config.Config, action string) {
+	a.fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "model":
+			if action == config.ActionCommit {
+				cfg.AICommitModel = a.model
+			} else {
+				cfg.AIIssueModel = a.model
+			}
+		case "base-url":
+			cfg.AIBaseURL = a.baseURL
+		case "temperature":
+			cfg.AITemperature = &a.temperature
+		case "reasoning-effort":
+			cfg.AIReasoningEffort = a.reasoningEffort
+		case "max-tokens":
+			cfg.AIMaxTokens = a.maxTokens
+		case "seed":
+			cfg.AISeed = &a.seed
+		}
+	})
+}
+
+// filterFlags는 diff 제외 규칙을 명령행에서 추가하는 플래그 모음이다.
+type filterFlags struct {
+	include    stringList
+	exclude    stringList
+	noDefaults bool
+}
+
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.include, "include", "only send files matching this glob to the AI (repeatable)")
+	fs.Var(&f.exclude, "exclude", "do not send files matching this glob to the AI (repeatable)")
+	fs.BoolVar(&f.noDefaults, "no-default-excludes", false, "disable the built-in lock file, vendor and generated code excludes")
+}
+
+// apply는 플래그로 받은 패턴을 설정 파일의 패턴 뒤에 덧붙인다.
+func (f *filterFlags) apply(cfg *config.Config) {
+	cfg.DiffInclude = append(cfg.DiffInclude, f.include...)
+	cfg.DiffExclude = append(cfg.DiffExclude, f.exclude...)
+	if f.noDefaults {
+		cfg.DiffNoDefaultExcludes = true
+	}
+}
+
+// stringList는 여러 번 지정할 수 있는 문자열 플래그다.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
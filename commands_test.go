@@ -0,0 +1,209 @@
+package main
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ledzpl/pcl/internal/config"
+	gittool "github.com/ledzpl/pcl/internal/git"
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+func TestParseCommitFlags(t *testing.T) {
+	opts, err := parseCommitFlags([]string{"--base", "main"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseCommitFlags() unexpected error: %v", err)
+	}
+	if opts.base != "main" {
+		t.Fatalf("base = %q, want main", opts.base)
+	}
+	if opts.configPath != "config.json" {
+		t.Fatalf("configPath = %q, want inherited config.json", opts.configPath)
+	}
+
+	if opts.source != gittool.SourceStaged {
+		t.Fatalf("source = %q, want default staged", opts.source)
+	}
+
+	if _, err := parseCommitFlags(nil, "config.json", io.Discard); err != nil {
+		t.Fatalf("parseCommitFlags() staged source should not require -base: %v", err)
+	}
+
+	if _, err := parseCommitFlags([]string{"--source", "worktree"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseCommitFlags() expected error when -base is missing for worktree source")
+	}
+
+	opts, err = parseCommitFlags([]string{"--base", "main", "--apply", "--yes"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseCommitFlags() unexpected error: %v", err)
+	}
+	if !opts.apply || !opts.yes {
+		t.Fatalf("parseCommitFlags() = %+v, want apply and yes", opts)
+	}
+
+	if _, err := parseCommitFlags([]string{"--base", "main", "--yes"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseCommitFlags() expected error for -yes without -apply")
+	}
+	if _, err := parseCommitFlags([]string{"--source", "worktree", "--base", "main", "--apply"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseCommitFlags() expected error for -apply with a source other than staged")
+	}
+}
+
+func TestParseIssueFlags(t *testing.T) {
+	opts, err := parseIssueFlags([]string{"--base", "develop", "--dry-run", "--review", "--config", "other.json"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseIssueFlags() unexpected error: %v", err)
+	}
+	if opts.base != "develop" || !opts.dryRun || !opts.review {
+		t.Fatalf("parseIssueFlags() = %+v, want base develop with dry-run and review", opts)
+	}
+	if opts.configPath != "other.json" {
+		t.Fatalf("configPath = %q, want other.json", opts.configPath)
+	}
+
+	if opts.source != gittool.SourceWorkingTree {
+		t.Fatalf("source = %q, want default worktree", opts.source)
+	}
+
+	opts, err = parseIssueFlags([]string{"--source", "committed", "--base", "main", "--force", "--json"}, "config.json", io.Discard)
+	if err != nil || opts.source != gittool.SourceCommitted || !opts.force || !opts.json {
+		t.Fatalf("parseIssueFlags() = %+v, %v, want committed source with force and json", opts, err)
+	}
+
+	if _, err := parseIssueFlags([]string{"--source", "everything"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseIssueFlags() expected error for unknown source")
+	}
+
+	if _, err := parseIssueFlags([]string{"--base", "main", "extra"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseIssueFlags() expected error for unexpected arguments")
+	}
+	opts, err = parseIssueFlags([]string{"--base", "main", "--label", "backend", "--label", "pcl", "--component", "cli", "--fix-version", "1.2.0", "--priority", " High ", "--sprint"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseIssueFlags() unexpected error: %v", err)
+	}
+	if f := opts.fields; strings.Join(f.labels, ",") != "backend,pcl" || strings.Join(f.components, ",") != "cli" || strings.Join(f.fixVersions, ",") != "1.2.0" || f.priority != "High" || !f.sprint {
+		t.Fatalf("parseIssueFlags() fields = %+v", f)
+	}
+	if _, err := parseIssueFlags([]string{"--base", "main", "--label", "needs review"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseIssueFlags() expected error for a label with spaces")
+	}
+	opts, err = parseIssueFlags([]string{"--base", "main", "--parent", "pcl-10", "--link", "blocks:PCL-12", "--link", "PCL-3"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseIssueFlags() unexpected error: %v", err)
+	}
+	wantLinks := []jira.Link{{Type: "Blocks", Key: "PCL-12"}, {Type: "Relates", Key: "PCL-3"}}
+	if opts.parent != "PCL-10" || !reflect.DeepEqual(opts.links, wantLinks) {
+		t.Fatalf("parseIssueFlags() parent = %q, links = %+v; want PCL-10 and %+v", opts.parent, opts.links, wantLinks)
+	}
+
+	if opts, err := parseIssueFlags([]string{"--base", "main", "--parent", "AUTO"}, "config.json", io.Discard); err != nil || opts.parent != parentAuto {
+		t.Fatalf("parseIssueFlags() parent = %q, %v; want auto", opts.parent, err)
+	}
+	if _, err := parseIssueFlags([]string{"--base", "main", "--parent", "epic"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseIssueFlags() expected error for invalid -parent")
+	}
+	if _, err := parseIssueFlags([]string{"--base", "main", "--link", "causes:PCL-1"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseIssueFlags() expected error for unknown link type")
+	}
+
+	if opts, err := parseIssueFlags([]string{"--base", "main", "--split", "--epic"}, "config.json", io.Discard); err != nil || !opts.split || !opts.epic {
+		t.Fatalf("parseIssueFlags() split = %v, epic = %v, %v; want both", opts.split, opts.epic, err)
+	}
+	if _, err := parseIssueFlags([]string{"--base", "main", "--epic"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseIssueFlags() expected error for -epic without -split")
+	}
+	if _, err := parseIssueFlags([]string{"--base", "main", "--split", "--epic", "--parent", "PCL-10"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseIssueFlags() expected error for -epic with -parent")
+	}
+}
+
+func TestParseUpdateFlags(t *testing.T) {
+	opts, err := parseUpdateFlags([]string{"--base", "main", "--issue", "pcl-7", "--dry-run"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseUpdateFlags() unexpected error: %v", err)
+	}
+	if opts.key != "PCL-7" || !opts.dryRun || opts.yes || opts.source != gittool.SourceWorkingTree {
+		t.Fatalf("parseUpdateFlags() = %+v, want PCL-7 dry-run on worktree", opts)
+	}
+
+	if opts, err := parseUpdateFlags([]string{"--base", "main", "--force"}, "config.json", io.Discard); err != nil || !opts.force {
+		t.Fatalf("parseUpdateFlags() force = %v, %v; want force", opts.force, err)
+	}
+	if _, err := parseUpdateFlags([]string{"--base", "main", "--issue", "seven"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseUpdateFlags() expected error for invalid -issue")
+	}
+	if _, err := parseUpdateFlags(nil, "config.json", io.Discard); err == nil {
+		t.Fatal("parseUpdateFlags() expected error when -base is missing for worktree source")
+	}
+}
+
+func TestRunCommandUnknown(t *testing.T) {
+	if err := runCommand([]string{"nope"}, "config.json"); err == nil {
+		t.Fatal("runCommand() expected error for unknown command")
+	}
+}
+
+func TestAIFlagsApplyOnlySetFlags(t *testing.T) {
+	opts, err := parseCommitFlags([]string{"--model", "gpt-5-mini", "--temperature", "0.5", "--seed", "7"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseCommitFlags() unexpected error: %v", err)
+	}
+
+	cfg := &config.Config{AIModel: "gpt-5", AIMaxTokens: 2048, AIBaseURL: "https://gateway.example.com/v1"}
+	opts.ai.apply(cfg, config.ActionCommit)
+
+	if got := cfg.ModelFor(config.ActionCommit); got != "gpt-5-mini" {
+		t.Fatalf("commit model = %q, want gpt-5-mini", got)
+	}
+	if got := cfg.ModelFor(config.ActionIssue); got != "gpt-5" {
+		t.Fatalf("issue model = %q, want untouched gpt-5", got)
+	}
+	if cfg.AITemperature == nil || *cfg.AITemperature != 0.5 {
+		t.Fatalf("temperature = %v, want 0.5", cfg.AITemperature)
+	}
+	if cfg.AISeed == nil || *cfg.AISeed != 7 {
+		t.Fatalf("seed = %v, want 7", cfg.AISeed)
+	}
+	if cfg.AIMaxTokens != 2048 || cfg.AIBaseURL != "https://gateway.example.com/v1" {
+		t.Fatalf("unset flags changed config: %+v", cfg)
+	}
+}
+
+func TestFilterFlagsAppendToConfig(t *testing.T) {
+	opts, err := parseIssueFlags([]string{"--base", "main", "--exclude", "*.md", "--exclude", "docs/", "--include", "internal/", "--no-default-excludes"}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseIssueFlags() unexpected error: %v", err)
+	}
+
+	cfg := &config.Config{DiffExclude: []string{"*.log"}}
+	opts.filter.apply(cfg)
+
+	if got := strings.Join(cfg.DiffExclude, " "); got != "*.log *.md docs/" {
+		t.Fatalf("DiffExclude = %q", got)
+	}
+	if got := strings.Join(cfg.DiffInclude, " "); got != "internal/" {
+		t.Fatalf("DiffInclude = %q", got)
+	}
+	if !cfg.DiffNoDefaultExcludes {
+		t.Fatal("DiffNoDefaultExcludes not set")
+	}
+}
+
+func TestParseTransitionFlags(t *testing.T) {
+	opts, err := parseTransitionFlags([]string{"--issue", "pcl-7", "--to", " In Review "}, "config.json", io.Discard)
+	if err != nil {
+		t.Fatalf("parseTransitionFlags() unexpected error: %v", err)
+	}
+	if opts.key != "PCL-7" || opts.to != "In Review" || opts.list {
+		t.Fatalf("parseTransitionFlags() = %+v", opts)
+	}
+
+	if _, err := parseTransitionFlags([]string{"--list", "--to", "Done"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseTransitionFlags() expected error for -list with -to")
+	}
+	if _, err := parseTransitionFlags([]string{"--issue", "seven"}, "config.json", io.Discard); err == nil {
+		t.Fatal("parseTransitionFlags() expected error for invalid -issue")
+	}
+}
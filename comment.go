@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ledzpl/pcl/internal/adf"
+	aitool "github.com/ledzpl/pcl/internal/ai"
+	"github.com/ledzpl/pcl/internal/config"
+	gittool "github.com/ledzpl/pcl/internal/git"
+	jira "github.com/ledzpl/pcl/internal/jira"
+	"github.com/ledzpl/pcl/internal/redact"
+)
+
+// commentStateFile은 이슈별로 마지막 댓글에 반영한 커밋을 기록하는 파일 이름이다. .git 디렉터리 안에 둔다.
+const commentStateFile = "pcl-comments.json"
+
+// commentCommitLimit는 댓글 프롬프트에 넣는 커밋 메시지 수의 상한이다.
+const commentCommitLimit = 50
+
+// commentFlow는 진행 상황 댓글 작성 과정의 선택 사항이다.
+type commentFlow struct {
+	// key는 댓글을 달 이슈 키다. 비어 있으면 브랜치·커밋에서 찾은 키를 쓴다.
+	key string
+	// base는 처음 댓글을 달 때 diff를 시작할 기준 브랜치다.
+	base string
+	// dryRun이 true면 댓글 내용만 보여주고 등록하지 않는다.
+	dryRun bool
+	// yes가 true면 확인 없이 등록한다.
+	yes bool
+}
+
+// commentRecord는 한 이슈에 마지막으로 단 댓글의 기록이다.
+type commentRecord struct {
+	Commit    string    `json:"commit"`
+	CommentID string    `json:"comment_id,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// commentState는 이슈 키별 commentRecord다.
+type commentState map[string]commentRecord
+
+// loadCommentState는 path의 기록을 읽는다. 파일이 없으면 빈 기록이다.
+func loadCommentState(path string) (commentState, error) {
+	state := commentState{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("댓글 기록을 읽을 수 없습니다: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("댓글 기록(%s)이 올바르지 않습니다: %w", path, err)
+	}
+	return state, nil
+}
+
+// save는 기록을 path에 쓴다.
+func (s commentState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// commentRange는 이슈에 댓글로 설명할 커밋 범위의 시작점을 고른다.
+// 마지막으로 댓글에 반영한 커밋이 HEAD의 조상이면 그 뒤부터, 아니면 base의 fork point부터다.
+func commentRange(last, base string) (string, error) {
+	if last != "" && gittool.IsAncestor(last) {
+		return last, nil
+	}
+	if base == "" {
+		if last != "" {
+			return "", fmt.Errorf("마지막 댓글의 커밋 %s를 현재 브랜치에서 찾을 수 없습니다. -base로 기준 브랜치를 지정하세요", shortSHA(last))
+		}
+		return "", errors.New("이 이슈에 단 댓글 기록이 없습니다. 처음에는 -base로 기준 브랜치를 지정하세요")
+	}
+	if last != "" {
+		fmt.Fprintf(os.Stderr, "마지막 댓글의 커밋 %s를 현재 브랜치에서 찾을 수 없어 %s 브랜치 기준으로 설명합니다.\n", shortSHA(last), base)
+	}
+	return gittool.ForkPoint(base), nil
+}
+
+// commentProgress는 마지막 댓글 이후의 커밋을 요약한 댓글을 생성해 보여준 뒤 이슈에 등록하고,
+// 등록한 HEAD 커밋을 기록해 다음 실행에서는 그 뒤의 작업만 설명한다.
+func commentProgress(cfg *config.Config, flow commentFlow) error {
+	if err := cfg.ValidateForJira(); err != nil {
+		return fmt.Errorf("설정이 올바르지 않습니다: %w", err)
+	}
+
+	key := flow.key
+	if key == "" {
+		key = detectIssueKey(cfg, flow.base)
+		if key == "" {
+			return fmt.Errorf("댓글을 달 이슈 키를 찾지 못했습니다. -issue로 지정하세요")
+		}
+		fmt.Printf("브랜치/커밋에서 찾은 %s 이슈에 댓글을 답니다.\n", key)
+	}
+
+	statePath, err := gittool.GitPath(commentStateFile)
+	if err != nil {
+		return err
+	}
+	state, err := loadCommentState(statePath)
+	if err != nil {
+		return err
+	}
+	head, err := gittool.HeadCommit()
+	if err != nil {
+		return err
+	}
+	last := state[key].Commit
+	if last == head {
+		fmt.Printf("%s 이슈에 마지막으로 댓글을 단 뒤 새 커밋이 없습니다.\n", key)
+		return nil
+	}
+	from, err := commentRange(last, flow.base)
+	if err != nil {
+		return err
+	}
+
+	patch, err := gittool.LoadRangePatch(from, head)
+	if err != nil {
+		return fmt.Errorf("diff를 가져올 수 없습니다: %w", err)
+	}
+	if patch.Empty() {
+		return errNoChanges
+	}
+	if patch, err = preparePatch(cfg, patch); err != nil {
+		return err
+	}
+	commits, err := gittool.CommitMessagesSince(from, commentCommitLimit)
+	if err != nil {
+		return err
+	}
+	if commits, err = redactCommitMessages(cfg, commits); err != nil {
+		return err
+	}
+
+	client := newJiraClient(cfg)
+	s := startSpinner("이슈 조회 중... ", "")
+	s.FinalMSG = ""
+	issue, err := client.GetIssue(context.Background(), key)
+	stopSpinner(s)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Jira issue %s: %w", key, err)
+	}
+
+	doc, err := progressComment(cfg, patch, issue, commits)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\n%s: %s (%s..%s)\n%s\n", issue.Key, issue.Fields.Summary, shortSHA(from), shortSHA(head), client.RenderDescription(doc))
+
+	if flow.dryRun {
+		return nil
+	}
+	if !flow.yes && !confirm("이 내용으로 댓글을 달까요") {
+		return errCanceled
+	}
+
+	s = startSpinner("Jira 댓글 등록 중... ", "Jira 댓글 등록 완료\n")
+	comment, err := client.AddComment(context.Background(), issue.Key, doc)
+	if err != nil {
+		s.FinalMSG = ""
+		stopSpinner(s)
+		return fmt.Errorf("failed to add Jira comment: %w", err)
+	}
+	stopSpinner(s)
+
+	state[issue.Key] = commentRecord{Commit: head, CommentID: comment.ID, At: time.Now().UTC()}
+	if err := state.save(statePath); err != nil {
+		return fmt.Errorf("댓글은 등록했지만 기록을 저장하지 못했습니다: %w", err)
+	}
+	applyConfiguredTransition(cfg, client, config.ActionComment, issue.Key)
+
+	fmt.Printf("%s %s\n", issue.Key, jira.BrowseURL(client.Host(), issue.Key))
+	return nil
+}
+
+// progressComment는 요약한 diff와 커밋 메시지로 진행 상황 댓글을 생성한다.
+func progressComment(cfg *config.Config, patch *gittool.Patch, issue *jira.Issue, commits []string) (*adf.Node, error) {
+	provider, err := newProvider(cfg, config.ActionIssue)
+	if err != nil {
+		return nil, err
+	}
+
+	s := startSpinner("진행 상황 댓글 생성 중... ", "")
+	s.FinalMSG = ""
+	defer stopSpinner(s)
+
+	ctx := context.Background()
+	diff, err := aitool.Condense(ctx, provider, patch, chunkOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize diff: %w", err)
+	}
+
+	doc, err := aitool.ProgressComment(ctx, provider, diff, aitool.CommentOptions{
+		Key:        issue.Key,
+		Summary:    issue.Fields.Summary,
+		Commits:    commits,
+		MaxRepairs: maxRepairs(cfg),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate progress comment: %w", err)
+	}
+	return doc, nil
+}
+
+// shortSHA는 출력용으로 커밋 SHA를 앞 7자리로 줄인다.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// redactCommitMessages는 모델에 보낼 커밋 메시지에도 diff와 같은 민감 정보 가리기를 적용한다.
+func redactCommitMessages(cfg *config.Config, messages []string) ([]string, error) {
+	redactor, err := newRedactor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var report redact.Report
+	out := make([]string, len(messages))
+	for i, m := range messages {
+		var r redact.Report
+		out[i], r = redactor.Text("커밋 메시지", m)
+		report.Findings = append(report.Findings, r.Findings...)
+	}
+	if err := checkRedaction(cfg, "커밋 메시지", report); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
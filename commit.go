@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	aitool "github.com/ledzpl/pcl/internal/ai"
+	"github.com/ledzpl/pcl/internal/config"
+	gittool "github.com/ledzpl/pcl/internal/git"
+
+	"github.com/manifoldco/promptui"
+)
+
+const (
+	reviewAccept     = "이 메시지로 커밋"
+	reviewEdit       = "$EDITOR로 수정"
+	reviewRegenerate = "다시 생성"
+	reviewCancel     = "취소"
+)
+
+var (
+	errNothingStaged = errors.New("스테이징된 변경 사항이 없습니다. git add 후 다시 시도하세요")
+	errCanceled      = errors.New("사용자가 작업을 취소했습니다")
+)
+
+// generateCommitMessage는 patch로 커밋 메시지를 생성해 표준 출력에 쓴다.
+func generateCommitMessage(cfg *config.Config, patch *gittool.Patch) error {
+	message, err := commitMessage(cfg, patch)
+	if err != nil {
+		return err
+	}
+	fmt.Println(message)
+	return nil
+}
+
+// commitChanges는 커밋 메시지를 생성하고 검토를 거친 뒤 스테이징된 변경 사항을 커밋한다.
+// skipReview가 true면 생성된 메시지를 그대로 사용한다.
+func commitChanges(cfg *config.Config, patch *gittool.Patch, skipReview bool) error {
+	staged, err := gittool.HasStagedChanges()
+	if err != nil {
+		return err
+	}
+	if !staged {
+		return errNothingStaged
+	}
+
+	message, err := commitMessage(cfg, patch)
+	if err != nil {
+		return err
+	}
+
+	if !skipReview {
+		message, err = reviewCommitMessage(cfg, patch, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := gittool.Commit(message); err != nil {
+		return err
+	}
+
+	fmt.Println("커밋을 생성했습니다.")
+	transitionAfterCommit(cfg)
+	return nil
+}
+
+// transitionAfterCommit은 jira_transitions.commit이 설정되어 있으면 브랜치 이름의 이슈를 그 상태로 옮긴다.
+// 커밋에는 Jira 설정이 필요 없으므로 설정이 부족하면 알리고 넘어간다.
+func transitionAfterCommit(cfg *config.Config) {
+	if cfg.TransitionFor(config.ActionCommit) == "" {
+		return
+	}
+	key := detectIssueKey(cfg, "")
+	if key == "" {
+		return
+	}
+	if err := cfg.ValidateForJira(); err != nil {
+		fmt.Fprintf(os.Stderr, "Jira 설정이 올바르지 않아 %s 이슈 상태를 바꾸지 않았습니다: %v\n", key, err)
+		return
+	}
+	applyConfiguredTransition(cfg, newJiraClient(cfg), config.ActionCommit, key)
+}
+
+// reviewCommitMessage는 사용자가 메시지를 승인할 때까지 수정·재생성을 반복한다.
+func reviewCommitMessage(cfg *config.Config, patch *gittool.Patch, message string) (string, error) {
+	for {
+		fmt.Printf("\n%s\n\n", message)
+
+		p := promptui.Select{
+			Label: "생성된 커밋 메시지",
+			Items: []string{reviewAccept, reviewEdit, reviewRegenerate, reviewCancel},
+		}
+		_, choice, err := p.Run()
+		if err != nil {
+			return "", errCanceled
+		}
+
+		switch choice {
+		case reviewAccept:
+			return message, nil
+		case reviewEdit:
+			edited, err := editText(message, "pcl-commit-*.txt")
+			if err != nil {
+				return "", err
+			}
+			if IsBlank(edited) {
+				return "", errCanceled
+			}
+			message = strings.TrimSpace(edited)
+		case reviewRegenerate:
+			message, err = commitMessage(cfg, patch)
+			if err != nil {
+				return "", err
+			}
+		default:
+			return "", errCanceled
+		}
+	}
+}
+
+func commitMessage(cfg *config.Config, patch *gittool.Patch) (string, error) {
+	if err := cfg.ValidateForAI(config.ActionCommit); err != nil {
+		return "", fmt.Errorf("설정이 올바르지 않습니다: %w", err)
+	}
+
+	provider, err := newProvider(cfg, config.ActionCommit)
+	if err != nil {
+		return "", err
+	}
+
+	s := startSpinner("커밋 메시지 생성 중... ", "커밋 메시지가 준비되었습니다.\n")
+	ctx := context.Background()
+	diff, err := aitool.Condense(ctx, provider, patch, chunkOptions(cfg))
+	if err != nil {
+		s.FinalMSG = ""
+		stopSpinner(s)
+		return "", fmt.Errorf("failed to summarize diff: %w", err)
+	}
+
+	message, err := aitool.CommitMessage(ctx, provider, diff)
+	if err != nil {
+		s.FinalMSG = ""
+		stopSpinner(s)
+		return "", fmt.Errorf("failed to generate commit message: %w", err)
+	}
+	stopSpinner(s)
+
+	if mode := cfg.IssueKeyMode(); mode != config.IssueKeyNone {
+		message = applyIssueKey(message, detectIssueKey(cfg, ""), mode)
+	}
+	return message, nil
+}
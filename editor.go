@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editText는 $VISUAL 또는 $EDITOR로 initial을 열고, 저장된 내용을 돌려준다.
+// 둘 다 비어 있으면 특정 편집기를 가정하지 않고 오류를 반환한다.
+func editText(initial, pattern string) (string, error) {
+	editor := strings.Fields(editorCommand())
+	if len(editor) == 0 {
+		return "", errors.New("editor: neither $VISUAL nor $EDITOR is set")
+	}
+
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("editor: create temp file: %w", err)
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", fmt.Errorf("editor: write temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("editor: close temp file: %w", err)
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor: run %s: %w", editor[0], err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("editor: read temp file: %w", err)
+	}
+	return string(data), nil
+}
+
+func editorCommand() string {
+	if v := strings.TrimSpace(os.Getenv("VISUAL")); v != "" {
+		return v
+	}
+	if v := strings.TrimSpace(os.Getenv("EDITOR")); v != "" {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditTextReturnsEditedContent(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "fake-editor.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nprintf 'edited' > \"$1\"\n"), 0o755); err != nil {
+		t.Fatalf("write fake editor: %v", err)
+	}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	got, err := editText("original", "pcl-test-*.txt")
+	if err != nil {
+		t.Fatalf("editText() unexpected error: %v", err)
+	}
+	if got != "edited" {
+		t.Fatalf("editText() = %q, want edited", got)
+	}
+}
+
+func TestEditTextRequiresEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", " ")
+
+	if _, err := editText("original", "pcl-test-*.txt"); err == nil {
+		t.Fatal("editText() expected error when no editor is set")
+	}
+}
+
+func TestEditorCommandPrefersVisual(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+
+	if got := editorCommand(); got != "code --wait" {
+		t.Fatalf("editorCommand() = %q, want VISUAL value", got)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gittool "github.com/ledzpl/pcl/internal/git"
+)
+
+const (
+	hookName   = "prepare-commit-msg"
+	hookMarker = "# pcl: prepare-commit-msg hook"
+)
+
+// hookScript는 스테이징된 diff로 커밋 메시지 파일을 미리 채우는 훅 스크립트다.
+// -m/-F 메시지(message), 병합(merge), 스쿼시(squash), amend·-c·-C(commit)는 건너뛴다.
+// pcl이 실패해도 커밋은 막지 않는다.
+const hookScript = `#!/bin/sh
+%s
+# pcl hook uninstall 로 제거할 수 있습니다.
+case "$2" in
+message|merge|squash|commit)
+	exit 0
+	;;
+esac
+
+%s -config %s commit -source staged -message-file "$1" || true
+exit 0
+`
+
+func runHookCommand(args []string, configPath string) error {
+	if len(args) == 0 {
+		return errors.New("hook: expected install or uninstall")
+	}
+
+	switch args[0] {
+	case "install":
+		fs := flag.NewFlagSet("hook install", flag.ContinueOnError)
+		fs.SetOutput(flag.CommandLine.Output())
+		fs.StringVar(&configPath, "config", configPath, "path to configuration file used by the hook")
+		force := fs.Bool("force", false, "overwrite an existing prepare-commit-msg hook not installed by pcl")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		return installHook(configPath, *force)
+	case "uninstall":
+		return uninstallHook()
+	default:
+		return fmt.Errorf("hook: unknown subcommand %q", args[0])
+	}
+}
+
+func installHook(configPath string, force bool) error {
+	path, err := gittool.HookPath(hookName)
+	if err != nil {
+		return err
+	}
+
+	if existing, err := os.ReadFile(path); err == nil {
+		if !strings.Contains(string(existing), hookMarker) && !force {
+			return fmt.Errorf("hook: %s already exists and was not installed by pcl (use -force to overwrite)", path)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("hook: read %s: %w", path, err)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("hook: locate pcl executable: %w", err)
+	}
+	absConfig, err := filepath.Abs(configPath)
+	if err != nil {
+		return fmt.Errorf("hook: resolve config path: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("hook: create hooks directory: %w", err)
+	}
+	script := fmt.Sprintf(hookScript, hookMarker, shellQuote(exe), shellQuote(absConfig))
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return fmt.Errorf("hook: write %s: %w", path, err)
+	}
+
+	fmt.Printf("%s 훅을 설치했습니다: %s\n", hookName, path)
+	return nil
+}
+
+func uninstallHook() error {
+	path, err := gittool.HookPath(hookName)
+	if err != nil {
+		return err
+	}
+
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Println("설치된 훅이 없습니다.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("hook: read %s: %w", path, err)
+	}
+	if !strings.Contains(string(existing), hookMarker) {
+		return fmt.Errorf("hook: %s was not installed by pcl; leaving it in place", path)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("hook: remove %s: %w", path, err)
+	}
+
+	fmt.Printf("%s 훅을 제거했습니다: %s\n", hookName, path)
+	return nil
+}
+
+// prependToFile은 message를 path 파일의 기존 내용(주석 템플릿 등) 앞에 기록한다.
+func prependToFile(path, message string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := io.WriteString(f, strings.TrimSpace(message)+"\n"); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if len(existing) > 0 {
+		if _, err := f.Write(append([]byte("\n"), existing...)); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return f.Close()
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallAndUninstallHook(t *testing.T) {
+	repoDir := t.TempDir()
+	if out, err := exec.Command("git", "init", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	chdir(t, repoDir)
+
+	hookPath := filepath.Join(repoDir, ".git", "hooks", hookName)
+
+	if err := installHook("config.json", false); err != nil {
+		t.Fatalf("installHook() unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(hookPath)
+	if err != nil {
+		t.Fatalf("hook not written: %v", err)
+	}
+	if info.Mode()&0o111 == 0 {
+		t.Fatalf("hook is not executable: %v", info.Mode())
+	}
+	content, _ := os.ReadFile(hookPath)
+	if !strings.Contains(string(content), hookMarker) || !strings.Contains(string(content), "-message-file") {
+		t.Fatalf("hook content unexpected:\n%s", content)
+	}
+
+	if err := installHook("config.json", false); err != nil {
+		t.Fatalf("installHook() should overwrite its own hook: %v", err)
+	}
+
+	if err := uninstallHook(); err != nil {
+		t.Fatalf("uninstallHook() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(hookPath); !os.IsNotExist(err) {
+		t.Fatalf("hook still present after uninstall: %v", err)
+	}
+}
+
+func TestInstallHookKeepsForeignHook(t *testing.T) {
+	repoDir := t.TempDir()
+	if out, err := exec.Command("git", "init", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	chdir(t, repoDir)
+
+	hookPath := filepath.Join(repoDir, ".git", "hooks", hookName)
+	if err := os.WriteFile(hookPath, []byte("#!/bin/sh\necho custom\n"), 0o755); err != nil {
+		t.Fatalf("write foreign hook: %v", err)
+	}
+
+	if err := installHook("config.json", false); err == nil {
+		t.Fatal("installHook() expected error for foreign hook")
+	}
+	if err := uninstallHook(); err == nil {
+		t.Fatal("uninstallHook() expected error for foreign hook")
+	}
+	if err := installHook("config.json", true); err != nil {
+		t.Fatalf("installHook(force) unexpected error: %v", err)
+	}
+}
+
+func TestPrependToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(path, []byte("# Please enter the commit message\n"), 0o644); err != nil {
+		t.Fatalf("write message file: %v", err)
+	}
+
+	if err := prependToFile(path, "feat: 훅 추가\n"); err != nil {
+		t.Fatalf("prependToFile() unexpected error: %v", err)
+	}
+
+	got, _ := os.ReadFile(path)
+	want := "feat: 훅 추가\n\n# Please enter the commit message\n"
+	if string(got) != want {
+		t.Fatalf("file content = %q, want %q", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got := shellQuote("it's"); got != `'it'\''s'` {
+		t.Fatalf("shellQuote() = %s", got)
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("chdir to %s: %v", dir, err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(cwd); err != nil {
+			t.Fatalf("restore cwd: %v", err)
+		}
+	})
+}
@@ -0,0 +1,227 @@
+// Package adf는 Jira 설명에 쓰는 Atlassian Document Format(ADF) 중
+// pcl이 허용하는 부분 집합의 타입과 검증을 제공한다.
+package adf
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// 허용하는 노드 타입.
+const (
+	TypeDoc        = "doc"
+	TypeHeading    = "heading"
+	TypeParagraph  = "paragraph"
+	TypeBulletList = "bulletList"
+	TypeListItem   = "listItem"
+	TypeTaskList   = "taskList"
+	TypeTaskItem   = "taskItem"
+	TypeCodeBlock  = "codeBlock"
+	TypeText       = "text"
+)
+
+// Node는 ADF 노드 하나다. 최상위 doc 노드도 같은 타입으로 표현한다.
+type Node struct {
+	Type    string         `json:"type"`
+	Version int            `json:"version,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content []*Node        `json:"content,omitempty"`
+	Text    string         `json:"text,omitempty"`
+	Marks   []Mark         `json:"marks,omitempty"`
+}
+
+// Mark는 text 노드의 서식이다.
+type Mark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// Error는 문서 안의 위치(Path)와 위반 내용이다. Path는 doc 기준이다 (예: content[2].attrs.localId).
+type Error struct {
+	Path    string
+	Message string
+}
+
+func (e Error) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var allowedMarks = map[string]bool{
+	"strong": true, "em": true, "code": true, "strike": true, "underline": true, "link": true,
+}
+
+// children은 블록 노드마다 허용하는 자식 타입이다.
+var children = map[string][]string{
+	TypeDoc:        {TypeHeading, TypeParagraph, TypeBulletList, TypeTaskList, TypeCodeBlock},
+	TypeHeading:    {TypeText},
+	TypeParagraph:  {TypeText},
+	TypeBulletList: {TypeListItem},
+	TypeListItem:   {TypeParagraph, TypeBulletList, TypeCodeBlock},
+	TypeTaskList:   {TypeTaskItem},
+	TypeTaskItem:   {TypeText},
+	TypeCodeBlock:  {TypeText},
+}
+
+// Doc은 content를 담은 version 1 문서를 만든다.
+func Doc(content ...*Node) *Node {
+	return &Node{Type: TypeDoc, Version: 1, Content: content}
+}
+
+// Paragraph는 text 하나를 담은 문단을 만든다.
+func Paragraph(text string) *Node {
+	return &Node{Type: TypeParagraph, Content: []*Node{{Type: TypeText, Text: text}}}
+}
+
+// Heading은 level 단계 제목을 만든다.
+func Heading(level int, text string) *Node {
+	return &Node{Type: TypeHeading, Attrs: map[string]any{"level": level}, Content: []*Node{{Type: TypeText, Text: text}}}
+}
+
+// BulletList는 항목마다 문단 하나를 담은 목록을 만든다.
+func BulletList(items ...string) *Node {
+	list := &Node{Type: TypeBulletList}
+	for _, item := range items {
+		list.Content = append(list.Content, &Node{Type: TypeListItem, Content: []*Node{Paragraph(item)}})
+	}
+	return list
+}
+
+// Validate는 doc이 허용 노드만으로 올바르게 구성됐는지 검사하고 위반을 모두 반환한다.
+func Validate(doc *Node) []Error {
+	if doc == nil {
+		return []Error{{Message: "document is missing"}}
+	}
+
+	var errs []Error
+	if doc.Type != TypeDoc {
+		errs = append(errs, Error{Path: "type", Message: fmt.Sprintf("must be %q, got %q", TypeDoc, doc.Type)})
+		return errs
+	}
+	if doc.Version != 1 {
+		errs = append(errs, Error{Path: "version", Message: fmt.Sprintf("must be 1, got %d", doc.Version)})
+	}
+	if len(doc.Content) == 0 {
+		errs = append(errs, Error{Path: "content", Message: "must not be empty"})
+	}
+	return validateChildren(doc, "", errs)
+}
+
+// Unsupported는 doc에 있는 허용되지 않은 노드와 서식 이름을 중복 없이 정렬해 반환한다 (서식은 "mark:이름").
+// 이 노드들은 Validate를 통과하는 문서로 다시 쓰면 사라진다.
+func Unsupported(doc *Node) []string {
+	found := map[string]bool{}
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		if n == nil {
+			return
+		}
+		if _, known := children[n.Type]; !known && n.Type != TypeText {
+			found[n.Type] = true
+		}
+		for _, m := range n.Marks {
+			if !allowedMarks[m.Type] {
+				found["mark:"+m.Type] = true
+			}
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return slices.Sorted(maps.Keys(found))
+}
+
+func validateChildren(parent *Node, path string, errs []Error) []Error {
+	allowed := children[parent.Type]
+	for i, child := range parent.Content {
+		childPath := fmt.Sprintf("%scontent[%d]", path, i)
+		if child == nil {
+			errs = append(errs, Error{Path: childPath, Message: "node is null"})
+			continue
+		}
+		if !slices.Contains(allowed, child.Type) {
+			msg := fmt.Sprintf("node type %q is not allowed in %s (allowed: %s)", child.Type, parent.Type, strings.Join(allowed, ", "))
+			if _, known := children[child.Type]; !known && child.Type != TypeText {
+				msg = fmt.Sprintf("node type %q is not allowed", child.Type)
+			}
+			errs = append(errs, Error{Path: childPath + ".type", Message: msg})
+			continue
+		}
+		errs = validateNode(child, childPath, errs)
+	}
+	return errs
+}
+
+func validateNode(n *Node, path string, errs []Error) []Error {
+	switch n.Type {
+	case TypeText:
+		if n.Text == "" {
+			errs = append(errs, Error{Path: path + ".text", Message: "must not be empty"})
+		}
+		if len(n.Content) > 0 {
+			errs = append(errs, Error{Path: path + ".content", Message: "text node must not have content"})
+		}
+		for i, m := range n.Marks {
+			if !allowedMarks[m.Type] {
+				errs = append(errs, Error{Path: fmt.Sprintf("%s.marks[%d].type", path, i), Message: fmt.Sprintf("mark %q is not allowed", m.Type)})
+			}
+		}
+		return errs
+	case TypeHeading:
+		level, ok := intAttr(n.Attrs, "level")
+		if !ok || level < 1 || level > 6 {
+			errs = append(errs, Error{Path: path + ".attrs.level", Message: "must be an integer between 1 and 6"})
+		}
+	case TypeTaskList:
+		errs = validateLocalID(n, path, errs)
+		if len(n.Content) == 0 {
+			errs = append(errs, Error{Path: path + ".content", Message: "must contain at least one taskItem"})
+		}
+	case TypeTaskItem:
+		errs = validateLocalID(n, path, errs)
+		if state, _ := n.Attrs["state"].(string); state != "TODO" && state != "DONE" {
+			errs = append(errs, Error{Path: path + ".attrs.state", Message: fmt.Sprintf("must be TODO or DONE, got %v", n.Attrs["state"])})
+		}
+	case TypeBulletList:
+		if len(n.Content) == 0 {
+			errs = append(errs, Error{Path: path + ".content", Message: "must contain at least one listItem"})
+		}
+	case TypeCodeBlock:
+		for i, c := range n.Content {
+			if c != nil && len(c.Marks) > 0 {
+				errs = append(errs, Error{Path: fmt.Sprintf("%s.content[%d].marks", path, i), Message: "text in codeBlock must not have marks"})
+			}
+		}
+	}
+	return validateChildren(n, path+".", errs)
+}
+
+func validateLocalID(n *Node, path string, errs []Error) []Error {
+	id, _ := n.Attrs["localId"].(string)
+	if !uuidPattern.MatchString(id) {
+		errs = append(errs, Error{Path: path + ".attrs.localId", Message: fmt.Sprintf("must be a UUID, got %q", id)})
+	}
+	return errs
+}
+
+// intAttr는 JSON에서 읽은 숫자(float64)와 코드에서 만든 int를 모두 정수로 읽는다.
+func intAttr(attrs map[string]any, key string) (int, bool) {
+	switch v := attrs[key].(type) {
+	case int:
+		return v, true
+	case float64:
+		if v != float64(int(v)) {
+			return 0, false
+		}
+		return int(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+package adf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildersProduceValidDocument(t *testing.T) {
+	doc := Doc(Heading(3, "배경"), Paragraph("본문"), BulletList("하나", "둘"))
+
+	if errs := Validate(doc); len(errs) > 0 {
+		t.Fatalf("Validate() = %v, want no errors", errs)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	var decoded Node
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error: %v", err)
+	}
+	if errs := Validate(&decoded); len(errs) > 0 {
+		t.Fatalf("Validate() after round trip = %v, want no errors", errs)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	doc := Doc(
+		Paragraph("x"),
+		&Node{Type: "table", Content: []*Node{{Type: "tableRow", Content: []*Node{{Type: "tableCell", Content: []*Node{Paragraph("셀")}}}}}},
+		&Node{Type: TypeParagraph, Content: []*Node{{Type: "mention"}, {Type: TypeText, Text: "y", Marks: []Mark{{Type: "textColor"}, {Type: "strong"}}}}},
+	)
+
+	want := "mark:textColor, mention, table, tableCell, tableRow"
+	if got := strings.Join(Unsupported(doc), ", "); got != want {
+		t.Fatalf("Unsupported() = %q, want %q", got, want)
+	}
+	if got := Unsupported(Doc(Paragraph("x"), BulletList("a"))); got != nil {
+		t.Fatalf("Unsupported() valid doc = %v, want nil", got)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  *Node
+		path string
+	}{
+		{"nil", nil, ""},
+		{"notDoc", Paragraph("x"), "type"},
+		{"emptyDoc", Doc(), "content"},
+		{"misplacedListItem", Doc(&Node{Type: TypeListItem, Content: []*Node{Paragraph("x")}}), "content[0].type"},
+		{"nestedUnknown", Doc(&Node{Type: TypeParagraph, Content: []*Node{{Type: "mention"}}}), "content[0].content[0].type"},
+		{"emptyText", Doc(&Node{Type: TypeParagraph, Content: []*Node{{Type: TypeText}}}), "content[0].content[0].text"},
+		{"badMark", Doc(&Node{Type: TypeParagraph, Content: []*Node{{Type: TypeText, Text: "x", Marks: []Mark{{Type: "textColor"}}}}}), "content[0].content[0].marks[0].type"},
+		{"emptyBulletList", Doc(&Node{Type: TypeBulletList}), "content[0].content"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			errs := Validate(caseData.doc)
+			if len(errs) != 1 || errs[0].Path != caseData.path {
+				t.Fatalf("Validate() = %v, want single error at %q", errs, caseData.path)
+			}
+		})
+	}
+}
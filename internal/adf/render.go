@@ -0,0 +1,93 @@
+package adf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Render는 doc을 터미널에서 읽기 좋은 일반 텍스트로 바꾼다.
+// 제목은 "#", 목록은 "-", 할 일은 "[ ]"/"[x]", 코드 블록은 4칸 들여쓰기로 표시한다.
+func Render(doc *Node) string {
+	if doc == nil {
+		return ""
+	}
+	var b strings.Builder
+	renderBlocks(&b, doc.Content, "")
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func renderBlocks(b *strings.Builder, nodes []*Node, indent string) {
+	for i, n := range nodes {
+		if n == nil {
+			continue
+		}
+		switch n.Type {
+		case TypeHeading:
+			level, ok := intAttr(n.Attrs, "level")
+			if !ok || level < 1 {
+				level = 1
+			}
+			fmt.Fprintf(b, "%s%s %s\n", indent, strings.Repeat("#", level), inlineText(n))
+		case TypeParagraph:
+			fmt.Fprintf(b, "%s%s\n", indent, inlineText(n))
+		case TypeBulletList:
+			for _, item := range n.Content {
+				if item == nil {
+					continue
+				}
+				renderListItem(b, item, indent)
+			}
+		case TypeTaskList:
+			for _, item := range n.Content {
+				if item == nil {
+					continue
+				}
+				box := "[ ]"
+				if state, _ := item.Attrs["state"].(string); state == "DONE" {
+					box = "[x]"
+				}
+				fmt.Fprintf(b, "%s%s %s\n", indent, box, inlineText(item))
+			}
+		case TypeCodeBlock:
+			for _, line := range strings.Split(inlineText(n), "\n") {
+				fmt.Fprintf(b, "%s    %s\n", indent, line)
+			}
+		case TypeText:
+			fmt.Fprintf(b, "%s%s\n", indent, n.Text)
+		default:
+			renderBlocks(b, n.Content, indent)
+		}
+
+		// 최상위 블록 사이만 빈 줄로 구분한다. 목록 안의 블록은 붙여 쓴다.
+		if indent == "" && i < len(nodes)-1 {
+			b.WriteByte('\n')
+		}
+	}
+}
+
+// renderListItem은 listItem의 첫 블록을 "- " 뒤에, 나머지 블록을 들여써서 출력한다.
+func renderListItem(b *strings.Builder, item *Node, indent string) {
+	var rest strings.Builder
+	renderBlocks(&rest, item.Content, indent+"  ")
+	text := strings.TrimPrefix(rest.String(), indent+"  ")
+	fmt.Fprintf(b, "%s- %s", indent, text)
+	if !strings.HasSuffix(text, "\n") {
+		b.WriteByte('\n')
+	}
+}
+
+// inlineText는 노드 안의 text를 이어 붙인다.
+func inlineText(n *Node) string {
+	var b strings.Builder
+	for _, c := range n.Content {
+		if c == nil {
+			continue
+		}
+		if c.Type == TypeText {
+			b.WriteString(c.Text)
+			continue
+		}
+		b.WriteString(inlineText(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package adf
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	nested := BulletList("하위 항목")
+	doc := Doc(
+		Heading(3, "배경"),
+		Paragraph("토큰이 AI로 전송된다"),
+		&Node{Type: TypeBulletList, Content: []*Node{
+			{Type: TypeListItem, Content: []*Node{Paragraph("정규식 규칙"), nested}},
+			{Type: TypeListItem, Content: []*Node{Paragraph("엔트로피 규칙")}},
+		}},
+		&Node{Type: TypeTaskList, Content: []*Node{
+			{Type: TypeTaskItem, Attrs: map[string]any{"state": "TODO"}, Content: []*Node{{Type: TypeText, Text: "오탐 확인"}}},
+			{Type: TypeTaskItem, Attrs: map[string]any{"state": "DONE"}, Content: []*Node{{Type: TypeText, Text: "설정 추가"}}},
+		}},
+		&Node{Type: TypeCodeBlock, Content: []*Node{{Type: TypeText, Text: "a := 1\nb := 2"}}},
+	)
+
+	want := `### 배경
+
+토큰이 AI로 전송된다
+
+- 정규식 규칙
+  - 하위 항목
+- 엔트로피 규칙
+
+[ ] 오탐 확인
+[x] 설정 추가
+
+    a := 1
+    b := 2
+`
+	if got := Render(doc); got != want {
+		t.Fatalf("Render() =\n%s\nwant\n%s", got, want)
+	}
+}
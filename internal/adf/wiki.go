@@ -0,0 +1,134 @@
+package adf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToWiki는 doc을 Jira Data Center/Server의 wiki markup으로 바꾼다.
+// REST API v2는 description에 ADF 대신 wiki markup 문자열을 받는다.
+func ToWiki(doc *Node) string {
+	if doc == nil {
+		return ""
+	}
+	var blocks []string
+	for _, n := range doc.Content {
+		if n == nil {
+			continue
+		}
+		if s := wikiBlock(n, 1); s != "" {
+			blocks = append(blocks, s)
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+func wikiBlock(n *Node, depth int) string {
+	switch n.Type {
+	case TypeHeading:
+		level, ok := intAttr(n.Attrs, "level")
+		if !ok || level < 1 || level > 6 {
+			level = 1
+		}
+		return fmt.Sprintf("h%d. %s", level, wikiInline(n))
+	case TypeParagraph:
+		return wikiInline(n)
+	case TypeBulletList:
+		var lines []string
+		for _, item := range n.Content {
+			if item != nil {
+				lines = append(lines, wikiListItem(item, depth)...)
+			}
+		}
+		return strings.Join(lines, "\n")
+	case TypeTaskList:
+		var lines []string
+		for _, item := range n.Content {
+			if item == nil {
+				continue
+			}
+			box := "☐"
+			if state, _ := item.Attrs["state"].(string); state == "DONE" {
+				box = "☑"
+			}
+			lines = append(lines, fmt.Sprintf("%s %s %s", strings.Repeat("*", depth), box, wikiInline(item)))
+		}
+		return strings.Join(lines, "\n")
+	case TypeCodeBlock:
+		return "{code}\n" + inlineText(n) + "\n{code}"
+	case TypeText:
+		return wikiText(n)
+	}
+	return ""
+}
+
+// wikiListItem은 listItem의 첫 문단을 "*" 항목으로, 중첩 목록을 한 단계 깊은 항목으로 만든다.
+func wikiListItem(item *Node, depth int) []string {
+	bullet := strings.Repeat("*", depth)
+	var lines []string
+	for i, c := range item.Content {
+		if c == nil {
+			continue
+		}
+		switch {
+		case c.Type == TypeBulletList || c.Type == TypeTaskList:
+			lines = append(lines, wikiBlock(c, depth+1))
+		case i == 0:
+			lines = append(lines, bullet+" "+wikiBlock(c, depth))
+		default:
+			lines = append(lines, wikiBlock(c, depth))
+		}
+	}
+	if len(lines) == 0 {
+		lines = append(lines, bullet)
+	}
+	return lines
+}
+
+func wikiInline(n *Node) string {
+	var b strings.Builder
+	for _, c := range n.Content {
+		if c != nil && c.Type == TypeText {
+			b.WriteString(wikiText(c))
+		}
+	}
+	return b.String()
+}
+
+var wikiEscaper = strings.NewReplacer(`{`, `\{`, `}`, `\}`, `[`, `\[`, `]`, `\]`)
+
+// wikiText는 text 노드에 서식(mark)을 적용한다.
+func wikiText(n *Node) string {
+	for _, m := range n.Marks {
+		if m.Type == "code" {
+			return "{{" + n.Text + "}}"
+		}
+	}
+
+	// 서식 기호는 공백과 붙어 있으면 적용되지 않으므로 앞뒤 공백은 바깥에 둔다.
+	core := strings.TrimSpace(n.Text)
+	if core == "" {
+		return n.Text
+	}
+	start := strings.Index(n.Text, core)
+	lead, trail := n.Text[:start], n.Text[start+len(core):]
+
+	s := wikiEscaper.Replace(core)
+	for _, m := range n.Marks {
+		switch m.Type {
+		case "strong":
+			s = "*" + s + "*"
+		case "em":
+			s = "_" + s + "_"
+		case "strike":
+			s = "-" + s + "-"
+		case "underline":
+			s = "+" + s + "+"
+		case "link":
+			if href, _ := m.Attrs["href"].(string); href != "" {
+				s = "[" + s + "|" + href + "]"
+			}
+		}
+	}
+	return lead + s + trail
+}
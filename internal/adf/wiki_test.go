@@ -0,0 +1,42 @@
+package adf
+
+import "testing"
+
+func TestToWiki(t *testing.T) {
+	doc := Doc(
+		Heading(3, "배경"),
+		&Node{Type: TypeParagraph, Content: []*Node{
+			{Type: TypeText, Text: "설정 "},
+			{Type: TypeText, Text: "jira_deployment", Marks: []Mark{{Type: "code"}}},
+			{Type: TypeText, Text: " 추가", Marks: []Mark{{Type: "strong"}}},
+			{Type: TypeText, Text: " 문서", Marks: []Mark{{Type: "link", Attrs: map[string]any{"href": "https://example.com"}}}},
+		}},
+		&Node{Type: TypeBulletList, Content: []*Node{
+			{Type: TypeListItem, Content: []*Node{Paragraph("인증 {Bearer}"), BulletList("PAT 사용")}},
+			{Type: TypeListItem, Content: []*Node{Paragraph("API v2")}},
+		}},
+		&Node{Type: TypeTaskList, Content: []*Node{
+			{Type: TypeTaskItem, Attrs: map[string]any{"state": "TODO"}, Content: []*Node{{Type: TypeText, Text: "사내 Jira 확인"}}},
+			{Type: TypeTaskItem, Attrs: map[string]any{"state": "DONE"}, Content: []*Node{{Type: TypeText, Text: "변환기 작성"}}},
+		}},
+		&Node{Type: TypeCodeBlock, Content: []*Node{{Type: TypeText, Text: `{"a": [1]}`}}},
+	)
+
+	want := `h3. 배경
+
+설정 {{jira_deployment}} *추가* [문서|https://example.com]
+
+* 인증 \{Bearer\}
+** PAT 사용
+* API v2
+
+* ☐ 사내 Jira 확인
+* ☑ 변환기 작성
+
+{code}
+{"a": [1]}
+{code}`
+	if got := ToWiki(doc); got != want {
+		t.Fatalf("ToWiki() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -3,11 +3,6 @@ package aitool
 import (
 	"context"
 	"fmt"
-	"log"
-	"strings"
-
-	"github.com/openai/openai-go/v2"
-	"github.com/openai/openai-go/v2/option"
 )
 
 const SYSPROMPT string = `
@@ -28,7 +23,8 @@ const PROMPT string = `
 이슈 작성 규칙:
 1) 사소한 변경 필터링
    - 전부가 주석 변경, 포매팅, 변수/함수 단순 리네이밍, 테스트 스냅샷 갱신 등이면
-     기본값: 이슈를 생성하지 말고 "null" 을 단독 반환.
+     기본값: 이슈를 생성하지 말고 아래 형태만 단독 반환(reason은 판단 근거를 한국어 한 문장으로).
+     {"skip": {"reason": "<이슈를 만들지 않는 이유>"}}
 2) 이슈 타입 판정(Story | Task 중 하나만)
    - Story: 사용자/클라이언트에 가치를 주는 새 기능/행동 변화, 공개 API/엔드포인트 추가, UI 변화, 데이터 모델 스키마 변경으로 기능적 요구가 생기는 경우.
    - Task: 리팩터링, 성능/안정화, 의존성/빌드/인프라 변경, 테스트 보강, 버그 수정(타입 제한상 Task로 분류).
@@ -61,7 +57,7 @@ const PROMPT string = `
 - JSON 파싱 가능 여부 확인.
 - issuetype.name은 Story 또는 Task 중 하나인지 확인.
 - description은 ADF 최상위에 "type":"doc","version":1" 이고 허용 노드만 포함하는지 확인.
-- 사소 변경만 있을 때는 "null" 단독 반환.
+- 사소 변경만 있을 때는 {"skip": {"reason": "..."}} 단독 반환.
 
 사용 스키마(값만 채워서 반환):
 {
@@ -80,27 +76,18 @@ const PROMPT string = `
   }
 }`
 
-func Analysis(diff, accountId, projectId, apiKey string) string {
-	client := openai.NewClient(option.WithAPIKey(apiKey))
-
-	resp, err := client.Chat.Completions.New(
-		context.Background(),
-		openai.ChatCompletionNewParams{
-			Model: openai.ChatModelGPT5,
-			Messages: []openai.ChatCompletionMessageParamUnion{
-				openai.SystemMessage(SYSPROMPT),
-				openai.UserMessage(fmt.Sprintf(PROMPT, projectId, accountId)),
-				openai.UserMessage(diff),
-			},
-			Seed: openai.Int(42),
-		},
-	)
+// Analysis는 diff를 분석해 Jira 이슈 생성용 JSON 페이로드를 반환한다.
+// 검증과 재요청은 GenerateIssue가 담당한다.
+func Analysis(ctx context.Context, p Provider, diff, accountId, projectId string) (string, error) {
+	return p.Complete(ctx, Request{Messages: issueMessages(diff, accountId, projectId)})
+}
 
-	if err != nil {
-		log.Fatal(err)
+func issueMessages(diff, accountId, projectId string) []Message {
+	return []Message{
+		{Role: RoleSystem, Content: SYSPROMPT},
+		{Role: RoleUser, Content: fmt.Sprintf(PROMPT, projectId, accountId)},
+		{Role: RoleUser, Content: diff},
 	}
-
-	return resp.Choices[0].Message.Content
 }
 
 const commitSystemPrompt string = `
@@ -118,25 +105,18 @@ const commitPrompt string = `
 - 테스트에 국한된 diff라면 type으로 test를 사용하고 간단히 요약합니다.
 - 출력은 추가 설명 없이 커밋 메시지 문자열만 반환합니다.`
 
-func CommitMessage(diff, apiKey string) string {
-	client := openai.NewClient(option.WithAPIKey(apiKey))
-
-	resp, err := client.Chat.Completions.New(
-		context.Background(),
-		openai.ChatCompletionNewParams{
-			Model: openai.ChatModelGPT5,
-			Messages: []openai.ChatCompletionMessageParamUnion{
-				openai.SystemMessage(commitSystemPrompt),
-				openai.UserMessage(commitPrompt),
-				openai.UserMessage(diff),
-			},
-			Seed: openai.Int(42),
+// CommitMessage는 diff를 분석해 Conventional Commits 형식의 커밋 메시지를 반환한다.
+func CommitMessage(ctx context.Context, p Provider, diff string) (string, error) {
+	message, err := p.Complete(ctx, Request{
+		Messages: []Message{
+			{Role: RoleSystem, Content: commitSystemPrompt},
+			{Role: RoleUser, Content: commitPrompt},
+			{Role: RoleUser, Content: diff},
 		},
-	)
-
+	})
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
 
-	return strings.TrimSpace(resp.Choices[0].Message.Content)
+	return StripCodeFence(message), nil
 }
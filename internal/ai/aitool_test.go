@@ -1,6 +1,7 @@
 package aitool
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -69,42 +70,7 @@ func TestAnalysisSendsExpectedPayload(t *testing.T) {
 			}
 		}
 
-		response := map[string]any{
-			"id":      "chatcmpl-test",
-			"object":  "chat.completion",
-			"created": 0,
-			"model":   "gpt-5",
-			"choices": []any{
-				map[string]any{
-					"index":         0,
-					"finish_reason": "stop",
-					"message": map[string]any{
-						"role":    "assistant",
-						"content": expectedContent,
-						"refusal": "",
-					},
-					"logprobs": map[string]any{
-						"content": []any{},
-						"refusal": []any{},
-					},
-				},
-			},
-			"usage": map[string]any{
-				"prompt_tokens":     1,
-				"completion_tokens": 1,
-				"total_tokens":      2,
-				"completion_tokens_details": map[string]any{
-					"accepted_prediction_tokens": 0,
-					"audio_tokens":               0,
-					"reasoning_tokens":           0,
-					"rejected_prediction_tokens": 0,
-				},
-				"prompt_tokens_details": map[string]any{
-					"audio_tokens":  0,
-					"cached_tokens": 0,
-				},
-			},
-		}
+		response := chatCompletionResponse(expectedContent)
 
 		reqCh <- capture
 
@@ -117,7 +83,15 @@ func TestAnalysisSendsExpectedPayload(t *testing.T) {
 
 	t.Setenv("OPENAI_BASE_URL", ts.URL+"/")
 
-	got := Analysis(diff, accountID, projectID, apiKey)
+	provider, err := NewProvider(ProviderConfig{APIKey: apiKey})
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+
+	got, err := Analysis(context.Background(), provider, diff, accountID, projectID)
+	if err != nil {
+		t.Fatalf("Analysis() error: %v", err)
+	}
 
 	capture := <-reqCh
 
@@ -163,6 +137,45 @@ func TestAnalysisSendsExpectedPayload(t *testing.T) {
 	}
 }
 
+func chatCompletionResponse(content string) map[string]any {
+	return map[string]any{
+		"id":      "chatcmpl-test",
+		"object":  "chat.completion",
+		"created": 0,
+		"model":   "gpt-5",
+		"choices": []any{
+			map[string]any{
+				"index":         0,
+				"finish_reason": "stop",
+				"message": map[string]any{
+					"role":    "assistant",
+					"content": content,
+					"refusal": "",
+				},
+				"logprobs": map[string]any{
+					"content": []any{},
+					"refusal": []any{},
+				},
+			},
+		},
+		"usage": map[string]any{
+			"prompt_tokens":     1,
+			"completion_tokens": 1,
+			"total_tokens":      2,
+			"completion_tokens_details": map[string]any{
+				"accepted_prediction_tokens": 0,
+				"audio_tokens":               0,
+				"reasoning_tokens":           0,
+				"rejected_prediction_tokens": 0,
+			},
+			"prompt_tokens_details": map[string]any{
+				"audio_tokens":  0,
+				"cached_tokens": 0,
+			},
+		},
+	}
+}
+
 func newIPv4Server(t *testing.T, handler http.Handler) *httptest.Server {
 	t.Helper()
 	listener, err := net.Listen("tcp4", "127.0.0.1:0")
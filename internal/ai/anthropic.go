@@ -0,0 +1,108 @@
+package aitool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultAnthropicBaseURL   = "https://api.anthropic.com"
+	anthropicVersion          = "2023-06-01"
+	defaultAnthropicMaxTokens = 4096
+)
+
+// anthropicProvider는 Anthropic Messages API(/v1/messages)를 사용한다.
+type anthropicProvider struct {
+	client *resty.Client
+	model  string
+	cfg    ProviderConfig
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	Temperature *float64           `json:"temperature,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+func newAnthropicProvider(cfg ProviderConfig) *anthropicProvider {
+	base := cfg.BaseURL
+	if base == "" {
+		base = defaultAnthropicBaseURL
+	}
+
+	c := resty.New().
+		SetBaseURL(strings.TrimSuffix(base, "/")).
+		SetTimeout(5*time.Minute).
+		SetHeader("Accept", "application/json").
+		SetHeader("Content-type", "application/json").
+		SetHeader("x-api-key", cfg.APIKey).
+		SetHeader("anthropic-version", anthropicVersion)
+
+	return &anthropicProvider{client: c, model: cfg.Model, cfg: cfg}
+}
+
+func (p *anthropicProvider) Complete(ctx context.Context, req Request) (string, error) {
+	system, rest := splitSystem(req.Messages)
+
+	// Messages API는 max_tokens가 필수이고 seed를 지원하지 않는다.
+	body := anthropicRequest{
+		Model:       p.model,
+		MaxTokens:   defaultAnthropicMaxTokens,
+		System:      system,
+		Messages:    make([]anthropicMessage, 0, len(rest)),
+		Temperature: p.cfg.Temperature,
+	}
+	if p.cfg.MaxTokens > 0 {
+		body.MaxTokens = p.cfg.MaxTokens
+	}
+	for _, m := range rest {
+		body.Messages = append(body.Messages, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+
+	var out anthropicResponse
+	resp, err := p.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetResult(&out).
+		Post("/v1/messages")
+	if err != nil {
+		return "", fmt.Errorf("aitool: anthropic request failed: %w", err)
+	}
+	if resp.IsError() {
+		return "", fmt.Errorf("aitool: anthropic request failed: status %d: %s", resp.StatusCode(), errorBody(resp))
+	}
+
+	var text strings.Builder
+	for _, block := range out.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	return text.String(), nil
+}
+
+func errorBody(resp *resty.Response) string {
+	body := strings.TrimSpace(string(resp.Body()))
+	if body == "" {
+		body = resp.Status()
+	}
+	return body
+}
@@ -0,0 +1,56 @@
+package aitool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ledzpl/pcl/internal/adf"
+)
+
+const commentSystemPrompt string = `
+당신은 팀에 작업 진행 상황을 공유하는 숙련된 개발자입니다.
+새 커밋과 git diff를 읽고, Jira 이슈에 남길 진행 상황 댓글을 한국어로 간결하게 작성합니다.
+이슈 설명을 다시 쓰지 말고 이번에 한 일만 보고하며, JSON 유효성과 ADF 적합성을 최우선으로 합니다.
+`
+
+const commentPrompt string = `
+Jira 이슈 %s(%s)에 남길 진행 상황 댓글을 작성해줘.
+아래에 지난 댓글 이후 추가된 커밋 메시지와 git diff를 줄게. 이 범위의 작업만 설명해.
+
+규칙:
+- 첫 문단에 이번에 진행한 내용을 한두 문장으로 요약.
+- 이어서 주요 변경점을 bulletList로 3~6개 정리. 파일 경로를 나열하지 말고 의미 단위로 묶어.
+- 남은 일이나 확인이 필요한 점이 diff에서 분명할 때만 taskList로 추가(attrs.localId는 UUID, state는 "TODO").
+- 근거는 커밋 메시지와 diff에 한정하고, 과장/가정 금지. 개인정보/비밀키/토큰/내부 URL 노출 금지.
+- 허용 노드만 사용: "doc, heading, paragraph, bulletList, listItem, taskList, taskItem, codeBlock".
+
+출력:
+- 오직 ADF 문서 JSON만 출력(추가 설명, 코드펜스, 주석 금지).
+- 최상위는 {"type": "doc", "version": 1, "content": [...]} 형태.`
+
+// CommentOptions는 ProgressComment 설정이다.
+type CommentOptions struct {
+	// Key와 Summary는 댓글을 달 이슈의 키와 제목이다.
+	Key     string
+	Summary string
+	// Commits는 지난 댓글 이후의 커밋 메시지다.
+	Commits []string
+	// MaxRepairs는 검증 오류를 되돌려 주며 다시 요청하는 최대 횟수다.
+	MaxRepairs int
+}
+
+// ProgressComment는 지난 댓글 이후의 커밋과 diff를 요약한 진행 상황 댓글을 ADF 문서로 생성한다.
+func ProgressComment(ctx context.Context, p Provider, diff string, opts CommentOptions) (*adf.Node, error) {
+	commits := "(커밋 메시지 없음)"
+	if len(opts.Commits) > 0 {
+		commits = "- " + strings.Join(opts.Commits, "\n- ")
+	}
+	messages := []Message{
+		{Role: RoleSystem, Content: commentSystemPrompt},
+		{Role: RoleUser, Content: fmt.Sprintf(commentPrompt, opts.Key, opts.Summary)},
+		{Role: RoleUser, Content: "커밋 메시지:\n" + commits},
+		{Role: RoleUser, Content: diff},
+	}
+	return generateDoc(ctx, p, messages, opts.MaxRepairs)
+}
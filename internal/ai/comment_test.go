@@ -0,0 +1,35 @@
+package aitool
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestProgressCommentSendsCommits(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) { return mergedDoc, nil }}
+
+	doc, err := ProgressComment(context.Background(), p, repairDiff, CommentOptions{
+		Key:        "PCL-7",
+		Summary:    "Jira 재시도 추가",
+		Commits:    []string{"Retry Jira requests", "Add backoff"},
+		MaxRepairs: 1,
+	})
+	if err != nil {
+		t.Fatalf("ProgressComment() error: %v", err)
+	}
+	if doc.Type != "doc" {
+		t.Fatalf("ProgressComment() = %+v", doc)
+	}
+
+	messages := p.requests[0].Messages
+	if messages[0].Content != commentSystemPrompt {
+		t.Fatalf("system prompt = %q, want commentSystemPrompt", messages[0].Content)
+	}
+	if !strings.Contains(messages[1].Content, "PCL-7(Jira 재시도 추가)") {
+		t.Fatalf("prompt missing issue: %q", messages[1].Content)
+	}
+	if messages[2].Content != "커밋 메시지:\n- Retry Jira requests\n- Add backoff" || messages[3].Content != repairDiff {
+		t.Fatalf("unexpected messages: %+v", messages)
+	}
+}
@@ -0,0 +1,85 @@
+package aitool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// ollamaProvider는 Ollama의 /api/chat 형식을 사용한다.
+type ollamaProvider struct {
+	client *resty.Client
+	model  string
+	cfg    ProviderConfig
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Options  map[string]any  `json:"options,omitempty"`
+}
+
+type ollamaResponse struct {
+	Message ollamaMessage `json:"message"`
+}
+
+func newOllamaProvider(cfg ProviderConfig) *ollamaProvider {
+	base := cfg.BaseURL
+	if base == "" {
+		base = defaultOllamaBaseURL
+	}
+
+	c := resty.New().
+		SetBaseURL(strings.TrimSuffix(base, "/")).
+		SetTimeout(10*time.Minute).
+		SetHeader("Accept", "application/json").
+		SetHeader("Content-type", "application/json")
+	if cfg.APIKey != "" {
+		c.SetAuthToken(cfg.APIKey)
+	}
+
+	return &ollamaProvider{client: c, model: cfg.Model, cfg: cfg}
+}
+
+func (p *ollamaProvider) Complete(ctx context.Context, req Request) (string, error) {
+	body := ollamaRequest{
+		Model:    p.model,
+		Messages: make([]ollamaMessage, 0, len(req.Messages)),
+		Options:  map[string]any{"seed": p.cfg.seed()},
+	}
+	if p.cfg.Temperature != nil {
+		body.Options["temperature"] = *p.cfg.Temperature
+	}
+	if p.cfg.MaxTokens > 0 {
+		body.Options["num_predict"] = p.cfg.MaxTokens
+	}
+	for _, m := range req.Messages {
+		body.Messages = append(body.Messages, ollamaMessage{Role: m.Role, Content: m.Content})
+	}
+
+	var out ollamaResponse
+	resp, err := p.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetResult(&out).
+		Post("/api/chat")
+	if err != nil {
+		return "", fmt.Errorf("aitool: ollama request failed: %w", err)
+	}
+	if resp.IsError() {
+		return "", fmt.Errorf("aitool: ollama request failed: status %d: %s", resp.StatusCode(), errorBody(resp))
+	}
+
+	return out.Message.Content, nil
+}
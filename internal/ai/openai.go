@@ -0,0 +1,104 @@
+package aitool
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+)
+
+const defaultOpenAIModel = openai.ChatModelGPT5
+
+// openAIProvider는 OpenAI Chat Completions 형식을 사용한다.
+// OpenAI, Azure OpenAI, llama.cpp 등 OpenAI 호환 서버가 모두 이 형식을 따른다.
+type openAIProvider struct {
+	client openai.Client
+	model  string
+	cfg    ProviderConfig
+}
+
+func newOpenAIProvider(cfg ProviderConfig) *openAIProvider {
+	opts := []option.RequestOption{}
+	if cfg.APIKey != "" {
+		opts = append(opts, option.WithAPIKey(cfg.APIKey))
+	}
+	if cfg.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(withTrailingSlash(cfg.BaseURL)))
+	}
+
+	model := cfg.Model
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+
+	return &openAIProvider{client: openai.NewClient(opts...), model: model, cfg: cfg}
+}
+
+// newAzureProvider는 Azure OpenAI 배포 엔드포인트로 요청을 보낸다.
+// Azure는 Authorization 대신 api-key 헤더와 api-version 쿼리를 요구한다.
+func newAzureProvider(cfg ProviderConfig) *openAIProvider {
+	apiVersion := cfg.APIVersion
+	if apiVersion == "" {
+		apiVersion = defaultAzureAPIVersion
+	}
+
+	base := withTrailingSlash(cfg.BaseURL) + "openai/deployments/" + cfg.Model + "/"
+	client := openai.NewClient(
+		option.WithBaseURL(base),
+		option.WithQueryAdd("api-version", apiVersion),
+		option.WithHeaderDel("authorization"),
+		option.WithHeader("api-key", cfg.APIKey),
+	)
+
+	return &openAIProvider{client: client, model: cfg.Model, cfg: cfg}
+}
+
+const defaultAzureAPIVersion = "2024-10-21"
+
+func (p *openAIProvider) Complete(ctx context.Context, req Request) (string, error) {
+	messages := make([]openai.ChatCompletionMessageParamUnion, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		switch m.Role {
+		case RoleSystem:
+			messages = append(messages, openai.SystemMessage(m.Content))
+		case RoleAssistant:
+			messages = append(messages, openai.AssistantMessage(m.Content))
+		default:
+			messages = append(messages, openai.UserMessage(m.Content))
+		}
+	}
+
+	params := openai.ChatCompletionNewParams{
+		Model:    p.model,
+		Messages: messages,
+		Seed:     openai.Int(p.cfg.seed()),
+	}
+	if p.cfg.Temperature != nil {
+		params.Temperature = openai.Float(*p.cfg.Temperature)
+	}
+	if p.cfg.MaxTokens > 0 {
+		params.MaxCompletionTokens = openai.Int(int64(p.cfg.MaxTokens))
+	}
+	if p.cfg.ReasoningEffort != "" {
+		params.ReasoningEffort = openai.ReasoningEffort(p.cfg.ReasoningEffort)
+	}
+
+	resp, err := p.client.Chat.Completions.New(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Choices) == 0 {
+		return "", errors.New("aitool: openai response has no choices")
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
+func withTrailingSlash(s string) string {
+	if strings.HasSuffix(s, "/") {
+		return s
+	}
+	return s + "/"
+}
@@ -0,0 +1,111 @@
+package aitool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// 지원하는 LLM 프로바이더 이름.
+const (
+	ProviderOpenAI           = "openai"
+	ProviderAzure            = "azure"
+	ProviderAnthropic        = "anthropic"
+	ProviderOllama           = "ollama"
+	ProviderOpenAICompatible = "openai-compatible"
+)
+
+// Providers는 설정에서 선택할 수 있는 프로바이더 목록이다.
+var Providers = []string{ProviderOpenAI, ProviderAzure, ProviderAnthropic, ProviderOllama, ProviderOpenAICompatible}
+
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message는 프로바이더에 전달하는 대화 메시지 하나다.
+type Message struct {
+	Role    string
+	Content string
+}
+
+// Request는 프로바이더 공통 요청이다. 프롬프트는 프로바이더와 무관하게 동일하다.
+type Request struct {
+	Messages []Message
+}
+
+// Provider는 채팅 형식의 요청을 LLM에 보내고 응답 텍스트를 돌려준다.
+type Provider interface {
+	Complete(ctx context.Context, req Request) (string, error)
+}
+
+// ProviderConfig는 프로바이더 생성에 필요한 접속 정보다.
+type ProviderConfig struct {
+	// Name은 Providers 중 하나다. 비어 있으면 openai를 사용한다.
+	Name   string
+	APIKey string
+	// BaseURL은 API 엔드포인트다. azure는 리소스 엔드포인트, ollama는 서버 주소를 뜻한다.
+	BaseURL string
+	// Model은 모델 이름이다. azure에서는 배포(deployment) 이름이다.
+	Model string
+	// APIVersion은 azure의 api-version 쿼리 값이다.
+	APIVersion string
+
+	// Temperature가 nil이면 프로바이더 기본값을 사용한다.
+	Temperature *float64
+	// ReasoningEffort는 minimal, low, medium, high 중 하나다. 추론 모델을 지원하는 OpenAI 형식에서만 사용한다.
+	ReasoningEffort string
+	// MaxTokens가 0이면 프로바이더 기본값을 사용한다.
+	MaxTokens int
+	// Seed가 nil이면 재현성을 위해 42를 사용한다.
+	Seed *int64
+}
+
+const defaultSeed int64 = 42
+
+func (c ProviderConfig) seed() int64 {
+	if c.Seed == nil {
+		return defaultSeed
+	}
+	return *c.Seed
+}
+
+// NewProvider는 cfg.Name에 맞는 Provider를 만든다.
+func NewProvider(cfg ProviderConfig) (Provider, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Name)) {
+	case "", ProviderOpenAI:
+		return newOpenAIProvider(cfg), nil
+	case ProviderOpenAICompatible:
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("aitool: %s provider requires a base URL", ProviderOpenAICompatible)
+		}
+		return newOpenAIProvider(cfg), nil
+	case ProviderAzure:
+		if cfg.BaseURL == "" || cfg.Model == "" {
+			return nil, fmt.Errorf("aitool: %s provider requires a base URL and deployment model", ProviderAzure)
+		}
+		return newAzureProvider(cfg), nil
+	case ProviderAnthropic:
+		return newAnthropicProvider(cfg), nil
+	case ProviderOllama:
+		return newOllamaProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("aitool: unknown provider %q", cfg.Name)
+	}
+}
+
+// splitSystem은 system 메시지를 합쳐 나머지 대화와 분리한다.
+// system 메시지를 별도 필드로 받는 프로바이더(anthropic)에서 사용한다.
+func splitSystem(messages []Message) (string, []Message) {
+	var system []string
+	rest := make([]Message, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == RoleSystem {
+			system = append(system, m.Content)
+			continue
+		}
+		rest = append(rest, m)
+	}
+	return strings.Join(system, "\n"), rest
+}
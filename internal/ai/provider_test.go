@@ -0,0 +1,304 @@
+package aitool
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+var testRequest = Request{
+	Messages: []Message{
+		{Role: RoleSystem, Content: "system prompt"},
+		{Role: RoleUser, Content: "instructions"},
+		{Role: RoleUser, Content: "diff"},
+	},
+}
+
+func TestOpenAICompatibleProvider(t *testing.T) {
+	var gotPath, gotAuth, gotModel string
+
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+
+		var body struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotModel = body.Model
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(chatCompletionResponse("compatible"))
+	}))
+	t.Cleanup(ts.Close)
+
+	p, err := NewProvider(ProviderConfig{
+		Name:    ProviderOpenAICompatible,
+		APIKey:  "local-key",
+		BaseURL: ts.URL + "/v1",
+		Model:   "qwen2.5-coder",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+
+	got, err := p.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if got != "compatible" {
+		t.Fatalf("Complete() = %q, want compatible", got)
+	}
+	if gotPath != "/v1/chat/completions" {
+		t.Fatalf("path = %q, want /v1/chat/completions", gotPath)
+	}
+	if gotAuth != "Bearer local-key" {
+		t.Fatalf("authorization = %q, want Bearer local-key", gotAuth)
+	}
+	if gotModel != "qwen2.5-coder" {
+		t.Fatalf("model = %q, want qwen2.5-coder", gotModel)
+	}
+}
+
+func TestAzureProvider(t *testing.T) {
+	var gotPath, gotVersion, gotKey, gotAuth string
+
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotVersion = r.URL.Query().Get("api-version")
+		gotKey = r.Header.Get("api-key")
+		gotAuth = r.Header.Get("Authorization")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(chatCompletionResponse("azure"))
+	}))
+	t.Cleanup(ts.Close)
+
+	t.Setenv("OPENAI_API_KEY", "must-not-leak")
+
+	p, err := NewProvider(ProviderConfig{
+		Name:       ProviderAzure,
+		APIKey:     "azure-key",
+		BaseURL:    ts.URL,
+		Model:      "gpt5-deployment",
+		APIVersion: "2024-10-21",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+
+	got, err := p.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if got != "azure" {
+		t.Fatalf("Complete() = %q, want azure", got)
+	}
+	if gotPath != "/openai/deployments/gpt5-deployment/chat/completions" {
+		t.Fatalf("path = %q", gotPath)
+	}
+	if gotVersion != "2024-10-21" {
+		t.Fatalf("api-version = %q, want 2024-10-21", gotVersion)
+	}
+	if gotKey != "azure-key" {
+		t.Fatalf("api-key = %q, want azure-key", gotKey)
+	}
+	if gotAuth != "" {
+		t.Fatalf("authorization header should not be sent to Azure, got %q", gotAuth)
+	}
+}
+
+func TestAnthropicProvider(t *testing.T) {
+	var (
+		gotPath, gotKey, gotVersion string
+		body                        anthropicRequest
+	)
+
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotKey = r.Header.Get("x-api-key")
+		gotVersion = r.Header.Get("anthropic-version")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type":"message","role":"assistant","content":[{"type":"text","text":"anthropic"}]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	p, err := NewProvider(ProviderConfig{
+		Name:    ProviderAnthropic,
+		APIKey:  "ant-key",
+		BaseURL: ts.URL,
+		Model:   "claude-test",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+
+	got, err := p.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if got != "anthropic" {
+		t.Fatalf("Complete() = %q, want anthropic", got)
+	}
+	if gotPath != "/v1/messages" {
+		t.Fatalf("path = %q, want /v1/messages", gotPath)
+	}
+	if gotKey != "ant-key" || gotVersion != anthropicVersion {
+		t.Fatalf("headers x-api-key=%q anthropic-version=%q", gotKey, gotVersion)
+	}
+	if body.System != "system prompt" {
+		t.Fatalf("system = %q, want system prompt", body.System)
+	}
+	if len(body.Messages) != 2 || body.Messages[0].Role != RoleUser {
+		t.Fatalf("messages = %+v, want two user messages without system", body.Messages)
+	}
+	if body.Model != "claude-test" || body.MaxTokens == 0 {
+		t.Fatalf("model=%q max_tokens=%d", body.Model, body.MaxTokens)
+	}
+}
+
+func TestAnthropicProviderReturnsErrorOnHTTPFailure(t *testing.T) {
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"type":"error"}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	p, _ := NewProvider(ProviderConfig{Name: ProviderAnthropic, BaseURL: ts.URL, Model: "claude-test"})
+	if _, err := p.Complete(context.Background(), testRequest); err == nil {
+		t.Fatal("Complete() expected error for HTTP 401")
+	}
+}
+
+func TestOllamaProvider(t *testing.T) {
+	var (
+		gotPath string
+		body    ollamaRequest
+	)
+
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"model":"llama3","message":{"role":"assistant","content":"ollama"},"done":true}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	p, err := NewProvider(ProviderConfig{Name: ProviderOllama, BaseURL: ts.URL, Model: "llama3"})
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+
+	got, err := p.Complete(context.Background(), testRequest)
+	if err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+	if got != "ollama" {
+		t.Fatalf("Complete() = %q, want ollama", got)
+	}
+	if gotPath != "/api/chat" {
+		t.Fatalf("path = %q, want /api/chat", gotPath)
+	}
+	if body.Stream {
+		t.Fatal("stream should be disabled")
+	}
+	if len(body.Messages) != 3 || body.Messages[0].Role != RoleSystem {
+		t.Fatalf("messages = %+v", body.Messages)
+	}
+}
+
+func TestNewProviderValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ProviderConfig
+	}{
+		{"unknown", ProviderConfig{Name: "bard"}},
+		{"compatibleWithoutBaseURL", ProviderConfig{Name: ProviderOpenAICompatible}},
+		{"azureWithoutDeployment", ProviderConfig{Name: ProviderAzure, BaseURL: "https://example.openai.azure.com"}},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			if _, err := NewProvider(caseData.cfg); err == nil {
+				t.Fatalf("NewProvider(%+v) expected error", caseData.cfg)
+			}
+		})
+	}
+}
+
+func TestOpenAIProviderGenerationParameters(t *testing.T) {
+	var body map[string]any
+
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(chatCompletionResponse("ok"))
+	}))
+	t.Cleanup(ts.Close)
+
+	temperature := 0.2
+	seed := int64(7)
+	p, err := NewProvider(ProviderConfig{
+		APIKey:          "key",
+		BaseURL:         ts.URL,
+		Model:           "gpt-5-mini",
+		Temperature:     &temperature,
+		ReasoningEffort: "low",
+		MaxTokens:       512,
+		Seed:            &seed,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+
+	if _, err := p.Complete(context.Background(), testRequest); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+
+	want := map[string]any{
+		"model":                 "gpt-5-mini",
+		"temperature":           0.2,
+		"reasoning_effort":      "low",
+		"max_completion_tokens": float64(512),
+		"seed":                  float64(7),
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("%s = %v, want %v", key, body[key], value)
+		}
+	}
+}
+
+func TestOllamaProviderGenerationParameters(t *testing.T) {
+	var body ollamaRequest
+
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"ok"}}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	temperature := 0.0
+	p, _ := NewProvider(ProviderConfig{
+		Name:        ProviderOllama,
+		BaseURL:     ts.URL,
+		Model:       "llama3",
+		Temperature: &temperature,
+		MaxTokens:   256,
+	})
+	if _, err := p.Complete(context.Background(), testRequest); err != nil {
+		t.Fatalf("Complete() error: %v", err)
+	}
+
+	if body.Options["temperature"] != 0.0 || body.Options["num_predict"] != float64(256) || body.Options["seed"] != float64(defaultSeed) {
+		t.Fatalf("options = %v", body.Options)
+	}
+}
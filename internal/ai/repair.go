@@ -0,0 +1,343 @@
+package aitool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledzpl/pcl/internal/adf"
+	gittool "github.com/ledzpl/pcl/internal/git"
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+// DefaultMaxRepairs는 검증에 실패한 응답을 모델에 다시 고쳐 달라고 요청하는 기본 횟수다.
+const DefaultMaxRepairs = 2
+
+// SkipError는 모델이 사소한 변경으로 판단해 이슈를 만들지 않았음을 뜻한다.
+// 응답이 null이거나 비어 있으면 Reason도 비어 있다.
+type SkipError struct {
+	Reason string
+}
+
+func (e *SkipError) Error() string {
+	if e.Reason == "" {
+		return "aitool: model decided no issue is needed"
+	}
+	return "aitool: model decided no issue is needed: " + e.Reason
+}
+
+var errSkipWhenForced = errors.New("이슈 생성을 요청했는데 skip 또는 null을 반환했습니다")
+
+const repairPrompt string = `
+이전 응답은 다음 이유로 Jira에 보낼 JSON으로 사용할 수 없습니다.
+%s
+
+위 문제만 고친 전체 JSON을 다시 반환해.
+처음 요구한 스키마와 규칙을 그대로 지키고, 추가 설명이나 코드펜스 없이 JSON만 출력해.`
+
+const forcePrompt string = `
+사용자가 사소한 변경이어도 이슈를 만들기로 했습니다.
+skip이나 null을 반환하지 말고, 반드시 스키마대로 이슈 JSON을 생성해.`
+
+// IssueOptions는 GenerateIssue 설정이다.
+type IssueOptions struct {
+	AccountID string
+	Project   string
+	// MaxRepairs는 검증 오류를 되돌려 주며 다시 요청하는 최대 횟수다.
+	MaxRepairs int
+	// Force가 true면 사소한 변경이어도 skip 없이 이슈를 만들도록 요청한다.
+	Force bool
+	// Patch가 있으면 모델이 끝내 올바른 페이로드를 만들지 못할 때 변경 파일 목록으로 기본 이슈를 만든다.
+	Patch *gittool.Patch
+	// Meta가 있으면 프로젝트의 이슈 타입과 필수 필드를 프롬프트에 알려 주고, 응답도 그 기준으로 검증한다.
+	// 컴포넌트 허용 값이 있으면 그중에서 제안하게 한다.
+	Meta *jira.ProjectMeta
+	// Labels는 이미 쓰고 있는 라벨이다. 모델은 이 중에서만 라벨을 제안할 수 있다.
+	Labels []string
+}
+
+// IssueResult는 GenerateIssue의 결과다.
+type IssueResult struct {
+	Payload *jira.IssuePayload
+	// Attempts는 모델에 보낸 요청 수다.
+	Attempts int
+	// Fallback이 true면 Payload는 모델 응답이 아니라 FallbackIssue로 만든 것이다.
+	Fallback bool
+	// LastError는 마지막으로 검증에 실패한 이유다. Fallback일 때만 채워진다.
+	LastError error
+}
+
+// GenerateIssue는 diff로 Jira 이슈 페이로드를 생성한다. 응답이 JSON으로 파싱되지 않거나
+// 검증에 실패하면 오류를 대화에 덧붙여 opts.MaxRepairs번까지 다시 요청한다.
+// 모델이 이슈가 필요 없다고 판단하면 *SkipError를 반환한다(opts.Force가 아닐 때).
+func GenerateIssue(ctx context.Context, p Provider, diff string, opts IssueOptions) (*IssueResult, error) {
+	messages := issueMessages(diff, opts.AccountID, opts.Project)
+	if opts.Meta != nil {
+		messages = append(messages, Message{Role: RoleUser, Content: projectMetaPrompt(opts.Meta)})
+	}
+	if prompt := suggestPrompt(opts.Labels, componentChoices(opts.Meta, "")); prompt != "" {
+		messages = append(messages, Message{Role: RoleUser, Content: prompt})
+	}
+	if opts.Force {
+		messages = append(messages, Message{Role: RoleUser, Content: forcePrompt})
+	}
+
+	var lastErr error
+	attempts := 0
+	for attempts <= max(opts.MaxRepairs, 0) {
+		attempts++
+		response, err := p.Complete(ctx, Request{Messages: messages})
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := parseIssueResponse(response, opts)
+		if err == nil {
+			return &IssueResult{Payload: payload, Attempts: attempts}, nil
+		}
+		var skip *SkipError
+		if errors.As(err, &skip) {
+			if !opts.Force {
+				return nil, err
+			}
+			err = errSkipWhenForced
+		}
+
+		lastErr = err
+		messages = append(messages,
+			Message{Role: RoleAssistant, Content: response},
+			Message{Role: RoleUser, Content: fmt.Sprintf(repairPrompt, describeProblems(err))},
+		)
+	}
+
+	if opts.Patch.Empty() {
+		return nil, fmt.Errorf("aitool: no valid issue payload after %d attempts: %w", attempts, lastErr)
+	}
+	fallback := FallbackIssue(opts.Patch, opts.AccountID, opts.Project)
+	if opts.Meta != nil {
+		fallback.Fields.IssueType.Name = opts.Meta.DefaultIssueType()
+		// 기본 이슈는 필수 커스텀 필드를 채우지 못하므로, Jira가 400으로 거절하기 전에 빠진 필드를 알린다.
+		if err := fallback.ValidateFor(opts.Meta); err != nil {
+			return nil, fmt.Errorf("aitool: no valid issue payload after %d attempts and the fallback issue is incomplete (%v): %w", attempts, err, lastErr)
+		}
+	}
+	return &IssueResult{
+		Payload:   fallback,
+		Attempts:  attempts,
+		Fallback:  true,
+		LastError: lastErr,
+	}, nil
+}
+
+// projectMetaPrompt는 프로젝트에서 쓸 수 있는 이슈 타입과, 채워야 하는 필수 필드·허용 값을 알려 준다.
+func projectMetaPrompt(meta *jira.ProjectMeta) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%s 프로젝트의 실제 설정에 맞춰 작성해.\n", meta.Project)
+	fmt.Fprintf(&b, "- issuetype.name은 다음 중 하나만 사용: %s\n", strings.Join(meta.TypeNames(), ", "))
+	b.WriteString("  (Story/Task가 없으면 위 규칙의 판정 기준에 가장 가까운 타입을 골라.)\n")
+
+	for _, t := range meta.IssueTypes {
+		if f, ok := t.Field("priority"); ok && len(f.AllowedValues) > 0 && !t.Subtask {
+			fmt.Fprintf(&b, "- 우선순위가 분명하면 \"priority\": {\"name\": \"<값>\"}을 넣어도 됨. 허용 값: %s\n", strings.Join(f.AllowedLabels(), ", "))
+			break
+		}
+	}
+
+	for _, t := range meta.IssueTypes {
+		required := t.RequiredFields()
+		if t.Subtask || len(required) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s 타입의 필수 필드(fields에 함께 넣어):\n", t.Name)
+		for _, f := range required {
+			fmt.Fprintf(&b, "  - %q (%s)", f.Key, f.Name)
+			if len(f.AllowedValues) > 0 {
+				key := "name"
+				if f.AllowedValues[0].Value != "" {
+					key = "value"
+				}
+				fmt.Fprintf(&b, `: {"%s": "<값>"} 형태, 허용 값 %s`, key, strings.Join(f.AllowedLabels(), ", "))
+				if f.Schema.Type == "array" {
+					b.WriteString(" (배열로)")
+				}
+			}
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// maxLabelChoices는 프롬프트에 보여주는 라벨 수의 상한이다.
+const maxLabelChoices = 200
+
+// suggestPrompt는 라벨과 컴포넌트를 기존 값 중에서만 고르도록 안내한다. 고를 값이 없으면 빈 문자열이다.
+func suggestPrompt(labels, components []string) string {
+	if len(labels) == 0 && len(components) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n변경 내용과 분명히 관련된 값이 있을 때만 아래 필드를 fields에 넣어. 목록에 없는 값은 만들지 마.\n")
+	if len(labels) > 0 {
+		fmt.Fprintf(&b, "- \"labels\": [\"<라벨>\"] 형태로 0~3개. 허용 값: %s\n", strings.Join(labels[:min(len(labels), maxLabelChoices)], ", "))
+	}
+	if len(components) > 0 {
+		fmt.Fprintf(&b, "- \"components\": [{\"name\": \"<컴포넌트>\"}] 형태로 0~2개. 허용 값: %s\n", strings.Join(components, ", "))
+	}
+	return b.String()
+}
+
+// componentChoices는 issueType의 컴포넌트 허용 값이다. issueType이 비었거나 없으면 컴포넌트 필드가 있는 첫 타입의 값이다.
+func componentChoices(meta *jira.ProjectMeta, issueType string) []string {
+	if meta == nil {
+		return nil
+	}
+	if t, ok := meta.IssueType(issueType); ok {
+		if f, ok := t.Field("components"); ok {
+			return f.AllowedLabels()
+		}
+	}
+	for _, t := range meta.IssueTypes {
+		if f, ok := t.Field("components"); ok && len(f.AllowedValues) > 0 && !t.Subtask {
+			return f.AllowedLabels()
+		}
+	}
+	return nil
+}
+
+// restrictSuggestions는 모델이 넣은 라벨과 컴포넌트 중 기존 값과 일치하는 것만 기존 표기로 남긴다.
+// 수정 버전은 모델에 맡기지 않으므로 지운다.
+func restrictSuggestions(f *jira.IssueFields, opts IssueOptions) {
+	f.Labels = pickExisting(f.Labels, opts.Labels)
+
+	names := pickExisting(jira.Names(f.Components), componentChoices(opts.Meta, f.IssueType.Name))
+	f.Components = nil
+	for _, n := range names {
+		f.Components = append(f.Components, jira.NamedRef{Name: n})
+	}
+	f.FixVersions = nil
+}
+
+// pickExisting은 values 중 existing에 대소문자 구분 없이 있는 값을 existing의 표기로, 중복 없이 반환한다.
+func pickExisting(values, existing []string) []string {
+	var out []string
+	for _, v := range values {
+		i := slices.IndexFunc(existing, func(e string) bool { return strings.EqualFold(e, strings.TrimSpace(v)) })
+		if i >= 0 && !slices.Contains(out, existing[i]) {
+			out = append(out, existing[i])
+		}
+	}
+	return out
+}
+
+func parseIssueResponse(response string, opts IssueOptions) (*jira.IssuePayload, error) {
+	response = StripCodeFence(response)
+	if skip := parseSkip(response); skip != nil {
+		return nil, skip
+	}
+
+	payload, err := jira.ParseIssuePayload(response)
+	if err != nil {
+		return nil, err
+	}
+	restrictSuggestions(&payload.Fields, opts)
+	if err := payload.ValidateFor(opts.Meta); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// parseSkip은 빈 응답, null, {"skip":{"reason":...}} 형태의 응답을 SkipError로 바꾼다.
+func parseSkip(response string) *SkipError {
+	switch response {
+	case "", "null", `"null"`:
+		return &SkipError{}
+	}
+
+	var out struct {
+		Skip *struct {
+			Reason string `json:"reason"`
+		} `json:"skip"`
+		Fields json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(response), &out); err != nil || out.Skip == nil || out.Fields != nil {
+		return nil
+	}
+	return &SkipError{Reason: strings.TrimSpace(out.Skip.Reason)}
+}
+
+// describeProblems는 검증 오류를 모델이 읽기 쉬운 목록으로 만든다.
+func describeProblems(err error) string {
+	var verr *jira.ValidationError
+	if !errors.As(err, &verr) {
+		return "- " + err.Error()
+	}
+	lines := make([]string, len(verr.Problems))
+	for i, p := range verr.Problems {
+		lines[i] = "- " + p.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// StripCodeFence는 응답 전체를 감싼 ``` 코드펜스(언어 표시 포함)를 벗겨낸다.
+func StripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") || len(s) < 6 {
+		return s
+	}
+
+	body := strings.TrimSuffix(s[3:], "```")
+	if nl := strings.IndexByte(body, '\n'); nl >= 0 && !strings.ContainsAny(body[:nl], "{[\"") {
+		body = body[nl+1:]
+	}
+	return strings.TrimSpace(body)
+}
+
+const fallbackNote = "AI 응답이 검증을 통과하지 못해 변경 파일 목록으로 자동 작성한 이슈입니다. 내용을 보완해 주세요."
+
+// FallbackIssue는 모델 없이 patch의 파일 목록만으로 Task 이슈 페이로드를 만든다.
+// 같은 patch에 대해 항상 같은 결과를 반환한다.
+func FallbackIssue(patch *gittool.Patch, accountID, project string) *jira.IssuePayload {
+	var added, removed int
+	items := make([]string, 0, len(patch.Files))
+	for _, line := range strings.Split(strings.TrimSpace(patch.Stat()), "\n") {
+		if line != "" {
+			items = append(items, line)
+		}
+	}
+	for _, f := range patch.Files {
+		added += f.Added
+		removed += f.Removed
+	}
+
+	payload := &jira.IssuePayload{Fields: jira.IssueFields{
+		Project:   jira.ProjectRef{Key: project},
+		Summary:   fallbackSummary(patch),
+		IssueType: jira.IssueTypeRef{Name: "Task"},
+		Description: adf.Doc(
+			adf.Paragraph(fallbackNote),
+			adf.Heading(3, fmt.Sprintf("변경 파일 %d개 (+%d -%d)", len(patch.Files), added, removed)),
+			adf.BulletList(items...),
+		),
+	}}
+	if accountID != "" {
+		payload.Fields.Assignee = &jira.UserRef{AccountID: accountID}
+	}
+	return payload
+}
+
+func fallbackSummary(patch *gittool.Patch) string {
+	first := patch.Files[0].Path()
+	summary := first + " 변경 사항 반영"
+	if n := len(patch.Files); n > 1 {
+		summary = fmt.Sprintf("%s 외 %d개 파일 변경 사항 반영", first, n-1)
+	}
+	if utf8.RuneCountInString(summary) > jira.MaxSummaryLength {
+		runes := []rune(summary)
+		summary = "…" + string(runes[len(runes)-jira.MaxSummaryLength+1:])
+	}
+	return summary
+}
@@ -0,0 +1,266 @@
+package aitool
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+const validIssueJSON = `{"fields":{"project":{"key":"PCL"},"summary":"diff 필터 추가","issuetype":{"name":"Task"},"assignee":{"accountId":"abc"},"description":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"잠금 파일 제외"}]}]}}}`
+
+const repairDiff = "diff --git a/internal/git/filter.go b/internal/git/filter.go\n@@ -1 +1,2 @@\n-a\n+b\n+c\n"
+
+func TestStripCodeFence(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain", ` {"a":1} `, `{"a":1}`},
+		{"fenced", "```\n{\"a\":1}\n```", `{"a":1}`},
+		{"fencedWithLanguage", "```json\n{\"a\":1}\n```\n", `{"a":1}`},
+		{"singleLine", "```{\"a\":1}```", `{"a":1}`},
+		{"commitMessage", "```\nfeat: 추가\n```", "feat: 추가"},
+		{"unterminated", "```json\n{}", "```json\n{}"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			if got := StripCodeFence(caseData.input); got != caseData.want {
+				t.Fatalf("StripCodeFence() = %q, want %q", got, caseData.want)
+			}
+		})
+	}
+}
+
+func TestGenerateIssueAcceptsFencedResponse(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) {
+		return "```json\n" + validIssueJSON + "\n```", nil
+	}}
+
+	result, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{AccountID: "abc", Project: "PCL", MaxRepairs: 2})
+	if err != nil {
+		t.Fatalf("GenerateIssue() error: %v", err)
+	}
+	if result.Attempts != 1 || result.Fallback {
+		t.Fatalf("result = %+v, want first attempt without fallback", result)
+	}
+	if result.Payload.Fields.Summary != "diff 필터 추가" {
+		t.Fatalf("summary = %q", result.Payload.Fields.Summary)
+	}
+}
+
+func TestGenerateIssueFeedsErrorsBack(t *testing.T) {
+	responses := []string{
+		`{"fields":{"project":{"key":"PCL"},"summary":"x","issuetype":{"name":"Bug"}}}`,
+		validIssueJSON,
+	}
+	p := &fakeProvider{}
+	p.respond = func(Request) (string, error) {
+		return responses[len(p.requests)-1], nil
+	}
+
+	result, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{Project: "PCL", MaxRepairs: 2})
+	if err != nil {
+		t.Fatalf("GenerateIssue() error: %v", err)
+	}
+	if result.Attempts != 2 {
+		t.Fatalf("Attempts = %d, want 2", result.Attempts)
+	}
+
+	second := p.requests[1].Messages
+	if len(second) != 5 {
+		t.Fatalf("repair request has %d messages, want 5", len(second))
+	}
+	if second[3].Role != RoleAssistant || second[3].Content != responses[0] {
+		t.Fatalf("previous response not echoed back: %+v", second[3])
+	}
+	feedback := second[4].Content
+	for _, want := range []string{"fields.issuetype.name", "fields.description"} {
+		if !strings.Contains(feedback, want) {
+			t.Fatalf("feedback %q missing %q", feedback, want)
+		}
+	}
+}
+
+func TestGenerateIssueFallsBackAfterMaxRepairs(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) {
+		return "이슈를 만들 수 없습니다", nil
+	}}
+	patch := mustParse(t, repairDiff)
+
+	result, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{AccountID: "abc", Project: "PCL", MaxRepairs: 1, Patch: patch})
+	if err != nil {
+		t.Fatalf("GenerateIssue() error: %v", err)
+	}
+	if len(p.requests) != 2 || !result.Fallback || result.LastError == nil {
+		t.Fatalf("requests = %d, result = %+v; want 2 requests and fallback", len(p.requests), result)
+	}
+	if err := result.Payload.Validate(); err != nil {
+		t.Fatalf("fallback payload invalid: %v", err)
+	}
+	if !reflect.DeepEqual(result.Payload, FallbackIssue(patch, "abc", "PCL")) {
+		t.Fatal("fallback payload is not deterministic")
+	}
+	if got := result.Payload.Fields.Summary; got != "internal/git/filter.go 변경 사항 반영" {
+		t.Fatalf("fallback summary = %q", got)
+	}
+}
+
+func TestGenerateIssueUsesProjectMeta(t *testing.T) {
+	meta := &jira.ProjectMeta{Project: "PCL", IssueTypes: []jira.IssueTypeMeta{
+		{ID: "1", Name: "Bug", Fields: []jira.FieldMeta{
+			{Key: "customfield_10100", Name: "Severity", Required: true, AllowedValues: []jira.AllowedValue{{ID: "20", Value: "S1"}}},
+		}},
+		{ID: "2", Name: "Sub-task", Subtask: true},
+	}}
+	// Task는 이 프로젝트에 없으므로 검증에 실패하고, 기본 타입 Bug로는 필수 필드를 채울 수 없어 오류가 된다.
+	p := &fakeProvider{respond: func(Request) (string, error) { return validIssueJSON, nil }}
+
+	_, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{
+		AccountID: "abc", Project: "PCL", MaxRepairs: 1, Patch: mustParse(t, repairDiff), Meta: meta,
+	})
+	for _, want := range []string{"fallback issue is incomplete", "fields.customfield_10100: Severity is required for Bug", "fields.issuetype.name"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("GenerateIssue() error = %v, want %q", err, want)
+		}
+	}
+
+	prompt := p.requests[0].Messages[len(p.requests[0].Messages)-1].Content
+	for _, want := range []string{"다음 중 하나만 사용: Bug\n", `"customfield_10100" (Severity)`, `{"value": "<값>"}`, "S1"} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("meta prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "Sub-task") {
+		t.Fatalf("meta prompt should not offer sub-task types:\n%s", prompt)
+	}
+
+	// 필수 필드에 기본값이 있으면 기본 타입으로 fallback한다.
+	meta.IssueTypes[0].Fields[0].HasDefault = true
+	result, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{
+		AccountID: "abc", Project: "PCL", MaxRepairs: 1, Patch: mustParse(t, repairDiff), Meta: meta,
+	})
+	if err != nil {
+		t.Fatalf("GenerateIssue() error: %v", err)
+	}
+	if !strings.Contains(result.LastError.Error(), "fields.issuetype.name") {
+		t.Fatalf("LastError = %v, want issue type problem", result.LastError)
+	}
+	if got := result.Payload.Fields.IssueType.Name; !result.Fallback || got != "Bug" {
+		t.Fatalf("fallback = %v, issue type = %q; want fallback Bug", result.Fallback, got)
+	}
+}
+
+func TestGenerateIssueRestrictsSuggestions(t *testing.T) {
+	meta := &jira.ProjectMeta{Project: "PCL", IssueTypes: []jira.IssueTypeMeta{
+		{ID: "1", Name: "Task", Fields: []jira.FieldMeta{
+			{Key: "components", Name: "Component/s", Schema: jira.FieldSchema{Type: "array", Items: "component"}, AllowedValues: []jira.AllowedValue{{ID: "7", Name: "cli"}, {ID: "8", Name: "jira"}}},
+		}},
+	}}
+	response := strings.Replace(validIssueJSON, `"assignee"`, `"labels":["Backend","made-up"],"components":[{"name":"CLI"},{"name":"web"}],"fixVersions":[{"name":"1.0"}],"assignee"`, 1)
+	p := &fakeProvider{respond: func(Request) (string, error) { return response, nil }}
+
+	result, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{
+		AccountID: "abc", Project: "PCL", Meta: meta, Labels: []string{"backend", "security"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateIssue() error: %v", err)
+	}
+
+	prompt := p.requests[0].Messages[len(p.requests[0].Messages)-1].Content
+	for _, want := range []string{"허용 값: backend, security\n", "허용 값: cli, jira\n"} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("suggestion prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	f := result.Payload.Fields
+	if strings.Join(f.Labels, ",") != "backend" || strings.Join(jira.Names(f.Components), ",") != "cli" || f.FixVersions != nil {
+		t.Fatalf("labels = %v, components = %v, fixVersions = %v; want only existing values", f.Labels, f.Components, f.FixVersions)
+	}
+}
+
+func TestGenerateIssueWithoutPatchReturnsError(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) { return "{", nil }}
+
+	_, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{Project: "PCL"})
+	if err == nil || !strings.Contains(err.Error(), "after 1 attempts") {
+		t.Fatalf("GenerateIssue() error = %v, want failure after 1 attempt", err)
+	}
+}
+
+func TestGenerateIssueSkip(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		reason   string
+	}{
+		{"null", "null", ""},
+		{"empty", "  ", ""},
+		{"fencedNull", "```json\nnull\n```", ""},
+		{"reason", `{"skip":{"reason":"주석만 바뀌었습니다"}}`, "주석만 바뀌었습니다"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			p := &fakeProvider{respond: func(Request) (string, error) { return caseData.response, nil }}
+
+			_, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{Project: "PCL", MaxRepairs: 2})
+			var skip *SkipError
+			if !errors.As(err, &skip) {
+				t.Fatalf("GenerateIssue() error = %v, want *SkipError", err)
+			}
+			if skip.Reason != caseData.reason {
+				t.Fatalf("Reason = %q, want %q", skip.Reason, caseData.reason)
+			}
+			if len(p.requests) != 1 {
+				t.Fatalf("requests = %d, want 1", len(p.requests))
+			}
+		})
+	}
+}
+
+func TestGenerateIssueForceRepairsSkip(t *testing.T) {
+	responses := []string{`{"skip":{"reason":"사소함"}}`, validIssueJSON}
+	p := &fakeProvider{}
+	p.respond = func(Request) (string, error) {
+		return responses[len(p.requests)-1], nil
+	}
+
+	result, err := GenerateIssue(context.Background(), p, repairDiff, IssueOptions{Project: "PCL", MaxRepairs: 1, Force: true})
+	if err != nil {
+		t.Fatalf("GenerateIssue() error: %v", err)
+	}
+	if result.Attempts != 2 || result.Fallback {
+		t.Fatalf("result = %+v, want model payload on second attempt", result)
+	}
+
+	first := p.requests[0].Messages
+	if last := first[len(first)-1]; last.Content != forcePrompt {
+		t.Fatalf("force instruction missing, last message = %q", last.Content)
+	}
+	second := p.requests[1].Messages
+	if !strings.Contains(second[len(second)-1].Content, errSkipWhenForced.Error()) {
+		t.Fatalf("repair feedback = %q", second[len(second)-1].Content)
+	}
+}
+
+func TestFallbackIssueTruncatesLongSummary(t *testing.T) {
+	long := strings.Repeat("d/", 60) + "file.go"
+	patch := mustParse(t, "diff --git a/"+long+" b/"+long+"\n@@ -1 +1 @@\n-a\n+b\n")
+
+	payload := FallbackIssue(patch, "", "PCL")
+	if err := payload.Validate(); err != nil {
+		t.Fatalf("fallback payload invalid: %v", err)
+	}
+	if payload.Fields.Assignee != nil {
+		t.Fatal("assignee set without account id")
+	}
+}
@@ -0,0 +1,190 @@
+package aitool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+// DefaultMaxSplitIssues는 SplitIssues가 제안하는 이슈 수의 기본 상한이다.
+const DefaultMaxSplitIssues = 5
+
+const splitPrompt string = `
+이번에는 이슈를 하나로 만들지 말고, diff를 변경 의도별로 묶어 서로 독립적으로 리뷰·배포할 수 있는 이슈 여러 개로 나눠줘.
+
+나누는 규칙:
+- 이슈는 1~%d개. 같은 목적의 변경(기능, 리팩터링, 의존성 정리 등)은 한 이슈로 묶고, 억지로 쪼개지 마.
+- 각 이슈는 위 스키마의 "fields" 객체를 그대로 따르고, 제목·설명은 그 이슈에 속한 변경만 다뤄.
+- 먼저 끝나야 하는 이슈가 앞에 오도록 정렬하고, 다른 이슈가 끝나야 진행할 수 있으면 "dependsOn"에 앞선 이슈의 번호(0부터)를 적어.
+%s
+출력 형태(오직 JSON만):
+{
+  "epic": %s,
+  "issues": [
+    { "fields": { ... }, "dependsOn": [] }
+  ]
+}`
+
+const splitEpicRule = `- 이슈들을 묶는 에픽 하나를 "epic"에 같은 "fields" 스키마로 만들어. issuetype.name은 %q이고, 설명에는 전체 목표와 하위 이슈 개요를 적어.
+- 하위 이슈의 issuetype.name에는 %q를 쓰지 마.
+`
+
+// SplitOptions는 SplitIssues 설정이다.
+type SplitOptions struct {
+	AccountID string
+	Project   string
+	// MaxIssues는 제안할 이슈 수의 상한이다. 0이면 DefaultMaxSplitIssues다.
+	MaxIssues int
+	// EpicType이 있으면 이슈들을 묶는 그 타입의 에픽도 함께 제안한다.
+	EpicType string
+	// MaxRepairs는 검증 오류를 되돌려 주며 다시 요청하는 최대 횟수다.
+	MaxRepairs int
+	// Force가 true면 사소한 변경이어도 skip 없이 이슈를 제안하도록 요청한다.
+	Force bool
+	// Meta와 Labels는 IssueOptions와 같다.
+	Meta   *jira.ProjectMeta
+	Labels []string
+}
+
+// SplitPlan은 diff를 나눈 이슈 제안이다.
+type SplitPlan struct {
+	// Epic은 이슈들을 묶는 에픽이다. SplitOptions.EpicType이 없으면 nil이다.
+	Epic   *jira.IssuePayload
+	Issues []SplitIssue
+}
+
+// SplitIssue는 제안된 이슈 하나와, 먼저 끝나야 하는 앞선 이슈의 번호다.
+type SplitIssue struct {
+	Payload   *jira.IssuePayload
+	DependsOn []int
+}
+
+type splitResponse struct {
+	Epic   *jira.IssuePayload `json:"epic"`
+	Issues []struct {
+		jira.IssuePayload
+		DependsOn []int `json:"dependsOn"`
+	} `json:"issues"`
+}
+
+// SplitIssues는 diff를 변경 의도별로 나눈 이슈 여러 개를 제안한다. 응답이 스키마나 검증에 맞지 않으면
+// 오류를 대화에 덧붙여 opts.MaxRepairs번까지 다시 요청한다. 모델이 이슈가 필요 없다고 판단하면 *SkipError를 반환한다(opts.Force가 아닐 때).
+func SplitIssues(ctx context.Context, p Provider, diff string, opts SplitOptions) (*SplitPlan, error) {
+	if opts.MaxIssues <= 0 {
+		opts.MaxIssues = DefaultMaxSplitIssues
+	}
+	issueOpts := IssueOptions{AccountID: opts.AccountID, Project: opts.Project, Meta: opts.Meta, Labels: opts.Labels}
+
+	messages := issueMessages(diff, opts.AccountID, opts.Project)
+	if opts.Meta != nil {
+		messages = append(messages, Message{Role: RoleUser, Content: projectMetaPrompt(opts.Meta)})
+	}
+	if prompt := suggestPrompt(opts.Labels, componentChoices(opts.Meta, "")); prompt != "" {
+		messages = append(messages, Message{Role: RoleUser, Content: prompt})
+	}
+	epicRule, epicShape := "", "null"
+	if opts.EpicType != "" {
+		epicRule, epicShape = fmt.Sprintf(splitEpicRule, opts.EpicType, opts.EpicType), `{ "fields": { ... } }`
+	}
+	messages = append(messages, Message{Role: RoleUser, Content: fmt.Sprintf(splitPrompt, opts.MaxIssues, epicRule, epicShape)})
+	if opts.Force {
+		messages = append(messages, Message{Role: RoleUser, Content: forcePrompt})
+	}
+
+	var lastErr error
+	attempts := 0
+	for attempts <= max(opts.MaxRepairs, 0) {
+		attempts++
+		response, err := p.Complete(ctx, Request{Messages: messages})
+		if err != nil {
+			return nil, err
+		}
+
+		plan, err := parseSplitResponse(response, opts, issueOpts)
+		if err == nil {
+			return plan, nil
+		}
+		var skip *SkipError
+		if errors.As(err, &skip) {
+			if !opts.Force {
+				return nil, err
+			}
+			err = errSkipWhenForced
+		}
+
+		lastErr = err
+		messages = append(messages,
+			Message{Role: RoleAssistant, Content: response},
+			Message{Role: RoleUser, Content: fmt.Sprintf(repairPrompt, describeProblems(err))},
+		)
+	}
+	return nil, fmt.Errorf("aitool: no valid split plan after %d attempts: %w", attempts, lastErr)
+}
+
+func parseSplitResponse(response string, opts SplitOptions, issueOpts IssueOptions) (*SplitPlan, error) {
+	response = StripCodeFence(response)
+	if skip := parseSkip(response); skip != nil {
+		return nil, skip
+	}
+
+	var out splitResponse
+	if err := json.Unmarshal([]byte(response), &out); err != nil {
+		return nil, fmt.Errorf("aitool: parse split plan: %w", err)
+	}
+
+	var problems []jira.FieldError
+	add := func(path, format string, args ...any) {
+		problems = append(problems, jira.FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	check := func(path string, payload *jira.IssuePayload) {
+		restrictSuggestions(&payload.Fields, issueOpts)
+		var verr *jira.ValidationError
+		if err := payload.ValidateFor(opts.Meta); errors.As(err, &verr) {
+			for _, p := range verr.Problems {
+				add(path+"."+p.Path, "%s", p.Message)
+			}
+		} else if err != nil {
+			add(path, "%s", err.Error())
+		}
+	}
+
+	plan := &SplitPlan{}
+	switch {
+	case opts.EpicType == "" && out.Epic != nil:
+		add("epic", "must be null")
+	case opts.EpicType != "" && out.Epic == nil:
+		add("epic", "must be an issue with issuetype %q", opts.EpicType)
+	case opts.EpicType != "":
+		check("epic", out.Epic)
+		if out.Epic.Fields.IssueType.Name != opts.EpicType {
+			add("epic.fields.issuetype.name", "must be %q, got %q", opts.EpicType, out.Epic.Fields.IssueType.Name)
+		}
+		plan.Epic = out.Epic
+	}
+
+	if len(out.Issues) == 0 || len(out.Issues) > opts.MaxIssues {
+		add("issues", "must contain 1 to %d issues, got %d", opts.MaxIssues, len(out.Issues))
+	}
+	for i, item := range out.Issues {
+		path := fmt.Sprintf("issues[%d]", i)
+		payload := item.IssuePayload
+		check(path, &payload)
+		if opts.EpicType != "" && payload.Fields.IssueType.Name == opts.EpicType {
+			add(path+".fields.issuetype.name", "must not be the epic type %q", opts.EpicType)
+		}
+		for _, d := range item.DependsOn {
+			if d < 0 || d >= i {
+				add(path+".dependsOn", "must refer to an earlier issue (0 to %d), got %d", i-1, d)
+			}
+		}
+		plan.Issues = append(plan.Issues, SplitIssue{Payload: &payload, DependsOn: item.DependsOn})
+	}
+
+	if len(problems) > 0 {
+		return nil, &jira.ValidationError{Problems: problems}
+	}
+	return plan, nil
+}
@@ -0,0 +1,118 @@
+package aitool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+const splitDesc = `"description":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"변경 설명"}]}]}`
+
+// splitIssue는 dependsOn이 비어 있지 않으면 "dependsOn"도 붙인 이슈 JSON을 만든다.
+func splitIssue(summary, issueType, dependsOn string) string {
+	issue := `{"fields":{"project":{"key":"PCL"},"summary":"` + summary + `","issuetype":{"name":"` + issueType + `"},` + splitDesc + `}`
+	if dependsOn != "" {
+		issue += `,"dependsOn":` + dependsOn
+	}
+	return issue + `}`
+}
+
+func TestSplitIssues(t *testing.T) {
+	meta := &jira.ProjectMeta{Project: "PCL", IssueTypes: []jira.IssueTypeMeta{
+		{ID: "1", Name: "Task"},
+		{ID: "2", Name: "Epic"},
+	}}
+	response := `{"epic":` + splitIssue("diff 필터 정비", "Epic", "") + `,"issues":[` +
+		splitIssue("필터 옵션 추가", "Task", "[]") + `,` +
+		splitIssue("README 갱신", "Task", "[0]") + `]}`
+	p := &fakeProvider{respond: func(Request) (string, error) { return response, nil }}
+
+	plan, err := SplitIssues(context.Background(), p, repairDiff, SplitOptions{Project: "PCL", EpicType: "Epic", Meta: meta})
+	if err != nil {
+		t.Fatalf("SplitIssues() error: %v", err)
+	}
+	if plan.Epic == nil || plan.Epic.Fields.Summary != "diff 필터 정비" {
+		t.Fatalf("Epic = %+v, want diff 필터 정비", plan.Epic)
+	}
+	if len(plan.Issues) != 2 || plan.Issues[1].Payload.Fields.Summary != "README 갱신" || len(plan.Issues[1].DependsOn) != 1 || plan.Issues[1].DependsOn[0] != 0 {
+		t.Fatalf("Issues = %+v, want two issues with the second depending on the first", plan.Issues)
+	}
+
+	prompt := p.requests[0].Messages[len(p.requests[0].Messages)-1].Content
+	for _, want := range []string{"1~5개", `issuetype.name은 "Epic"`} {
+		if !strings.Contains(prompt, want) {
+			t.Fatalf("split prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
+
+func TestSplitIssuesFeedsErrorsBack(t *testing.T) {
+	responses := []string{
+		`{"epic":` + splitIssue("에픽", "Task", "") + `,"issues":[` + splitIssue("작업", "Task", "[0]") + `]}`,
+		`{"epic":null,"issues":[` + splitIssue("작업", "Task", "") + `]}`,
+	}
+	p := &fakeProvider{}
+	p.respond = func(Request) (string, error) {
+		return responses[len(p.requests)-1], nil
+	}
+
+	plan, err := SplitIssues(context.Background(), p, repairDiff, SplitOptions{Project: "PCL", MaxRepairs: 1})
+	if err != nil {
+		t.Fatalf("SplitIssues() error: %v", err)
+	}
+	if plan.Epic != nil || len(plan.Issues) != 1 {
+		t.Fatalf("plan = %+v, want one issue without epic", plan)
+	}
+
+	second := p.requests[1].Messages
+	feedback := second[len(second)-1].Content
+	for _, want := range []string{"epic: must be null", "issues[0].dependsOn"} {
+		if !strings.Contains(feedback, want) {
+			t.Fatalf("feedback %q missing %q", feedback, want)
+		}
+	}
+}
+
+func TestSplitIssuesErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		opts     SplitOptions
+		want     string
+	}{
+		{"noIssues", `{"epic":null,"issues":[]}`, SplitOptions{}, "issues: must contain 1 to 5 issues"},
+		{"tooMany", `{"epic":null,"issues":[` + splitIssue("a", "Task", "") + `,` + splitIssue("b", "Task", "") + `]}`, SplitOptions{MaxIssues: 1}, "must contain 1 to 1 issues, got 2"},
+		{"missingEpic", `{"epic":null,"issues":[` + splitIssue("a", "Task", "") + `]}`, SplitOptions{EpicType: "Epic"}, `epic: must be an issue with issuetype "Epic"`},
+		{"invalidIssue", `{"epic":null,"issues":[{"fields":{"summary":"a"}}]}`, SplitOptions{}, "issues[0].fields.project.key"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			p := &fakeProvider{respond: func(Request) (string, error) { return caseData.response, nil }}
+			opts := caseData.opts
+			opts.Project = "PCL"
+
+			_, err := SplitIssues(context.Background(), p, repairDiff, opts)
+			if err == nil || !strings.Contains(err.Error(), caseData.want) {
+				t.Fatalf("SplitIssues() error = %v, want %q", err, caseData.want)
+			}
+		})
+	}
+}
+
+func TestSplitIssuesSkip(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) { return `{"skip":{"reason":"주석만 바뀌었습니다"}}`, nil }}
+
+	_, err := SplitIssues(context.Background(), p, repairDiff, SplitOptions{Project: "PCL", MaxRepairs: 2})
+	var skip *SkipError
+	if !errors.As(err, &skip) || skip.Reason != "주석만 바뀌었습니다" {
+		t.Fatalf("SplitIssues() error = %v, want *SkipError", err)
+	}
+	if len(p.requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(p.requests))
+	}
+}
@@ -0,0 +1,176 @@
+package aitool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	gittool "github.com/ledzpl/pcl/internal/git"
+)
+
+const (
+	// DefaultContextTokens는 한 번에 보낼 diff의 기본 토큰 예산이다.
+	DefaultContextTokens = 60000
+	// DefaultConcurrency는 조각 요약을 동시에 요청하는 기본 개수다.
+	DefaultConcurrency = 4
+
+	maxReduceDepth = 3
+)
+
+const summarizeSystemPrompt string = `
+당신은 대규모 코드 변경을 검토하는 숙련된 소프트웨어 엔지니어입니다.
+큰 diff의 일부 조각만 보고, 나중에 다른 조각 요약과 합쳐질 수 있도록 사실 위주로 요약합니다.
+모든 출력은 한국어로 작성합니다.`
+
+const summarizePrompt string = `
+다음은 큰 git diff를 파일/헌크 단위로 나눈 조각(%d/%d)입니다. 이 조각의 변경 사항을 요약해줘.
+
+지침:
+- "- " bullet 목록으로만 출력하고, 각 항목은 한 문장으로 작성합니다.
+- 변경된 파일/모듈, 변경 의도, 동작 변화, 새로 생긴 API/설정을 우선합니다.
+- 주석·포매팅만 바뀐 부분은 "사소한 변경"으로 한 줄에 묶습니다.
+- diff에 없는 내용은 추측하지 않습니다.
+- 비밀키/토큰/개인정보는 포함하지 않습니다.`
+
+const summaryHeader string = `아래는 diff가 너무 커서 파일/헌크 단위로 나눠 요약한 변경 내역입니다. 원본 diff 대신 이 요약을 근거로 작성해줘.
+`
+
+// ChunkOptions는 큰 diff를 나눠 요약할 때의 예산과 동시성이다.
+type ChunkOptions struct {
+	// ContextTokens는 한 요청에 담을 diff의 최대 추정 토큰 수다. 0이면 DefaultContextTokens.
+	ContextTokens int
+	// Concurrency는 동시에 보낼 요약 요청 수다. 0이면 DefaultConcurrency.
+	Concurrency int
+}
+
+func (o ChunkOptions) budget() int {
+	if o.ContextTokens > 0 {
+		return o.ContextTokens
+	}
+	return DefaultContextTokens
+}
+
+func (o ChunkOptions) concurrency() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+	return DefaultConcurrency
+}
+
+// EstimateTokens는 text의 토큰 수를 대략 추정한다.
+// ASCII는 약 4자당 1토큰, 한글 등 비 ASCII 문자는 1자당 1토큰으로 계산한다.
+func EstimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// Condense는 patch가 토큰 예산을 넘으면 파일/헌크 조각별로 나눠 동시에 요약하고(map),
+// 변경 파일 목록과 요약을 이어 붙인 텍스트를 돌려준다. 이 텍스트는 Analysis/CommitMessage에
+// diff 대신 전달해 최종 결과로 합친다(reduce). 예산 이내인 patch는 diff 원문을 반환한다.
+func Condense(ctx context.Context, p Provider, patch *gittool.Patch, opts ChunkOptions) (string, error) {
+	diff := patch.String()
+	budget := opts.budget()
+	if EstimateTokens(diff) <= budget {
+		return diff, nil
+	}
+
+	summaries, err := summarizeChunks(ctx, p, patch.Chunks(budget, EstimateTokens), opts.concurrency())
+	if err != nil {
+		return "", err
+	}
+
+	return reduce(ctx, p, joinSummaries(patch.Stat(), summaries), opts, 1)
+}
+
+// reduce는 합친 요약이 여전히 예산을 넘으면 줄 단위로 나눠 다시 요약한다.
+func reduce(ctx context.Context, p Provider, text string, opts ChunkOptions, depth int) (string, error) {
+	budget := opts.budget()
+	if EstimateTokens(text) <= budget {
+		return text, nil
+	}
+	if depth >= maxReduceDepth {
+		return "", fmt.Errorf("aitool: diff is still larger than %d tokens after %d summarization passes", budget, depth)
+	}
+
+	summaries, err := summarizeChunks(ctx, p, gittool.ChunkText(text, budget, EstimateTokens), opts.concurrency())
+	if err != nil {
+		return "", err
+	}
+
+	return reduce(ctx, p, joinSummaries("", summaries), opts, depth+1)
+}
+
+func joinSummaries(stat string, summaries []string) string {
+	var b strings.Builder
+	b.WriteString(summaryHeader)
+	if stat != "" {
+		fmt.Fprintf(&b, "\n### 변경 파일\n%s", stat)
+	}
+	for i, s := range summaries {
+		fmt.Fprintf(&b, "\n### 조각 %d/%d\n%s\n", i+1, len(summaries), strings.TrimSpace(s))
+	}
+	return b.String()
+}
+
+// summarizeChunks는 최대 concurrency개씩 동시에 조각을 요약하고, 입력 순서대로 결과를 돌려준다.
+// 하나라도 실패하면 나머지 요청을 취소하고 첫 오류를 반환한다.
+func summarizeChunks(ctx context.Context, p Provider, chunks []string, concurrency int) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, concurrency)
+		out      = make([]string, len(chunks))
+	)
+
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			summary, err := p.Complete(ctx, Request{
+				Messages: []Message{
+					{Role: RoleSystem, Content: summarizeSystemPrompt},
+					{Role: RoleUser, Content: fmt.Sprintf(summarizePrompt, i+1, len(chunks))},
+					{Role: RoleUser, Content: chunk},
+				},
+			})
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("aitool: summarize chunk %d/%d: %w", i+1, len(chunks), err)
+					cancel()
+				})
+				return
+			}
+			out[i] = summary
+		}(i, chunk)
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
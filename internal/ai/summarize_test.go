@@ -0,0 +1,119 @@
+package aitool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gittool "github.com/ledzpl/pcl/internal/git"
+)
+
+// fakeProvider는 요청을 기록하고 respond의 결과를 돌려주는 테스트용 Provider다.
+type fakeProvider struct {
+	mu       sync.Mutex
+	requests []Request
+	respond  func(req Request) (string, error)
+}
+
+func (f *fakeProvider) Complete(ctx context.Context, req Request) (string, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+	return f.respond(req)
+}
+
+func mustParse(t *testing.T, raw string) *gittool.Patch {
+	t.Helper()
+
+	p, err := gittool.ParsePatch(raw)
+	if err != nil {
+		t.Fatalf("ParsePatch() error: %v", err)
+	}
+	return p
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens("abcdefgh"); got != 2 {
+		t.Fatalf("EstimateTokens(ascii) = %d, want 2", got)
+	}
+	if got := EstimateTokens("한글"); got != 2 {
+		t.Fatalf("EstimateTokens(hangul) = %d, want 2", got)
+	}
+}
+
+func TestCondenseReturnsSmallDiffUnchanged(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) {
+		return "", errors.New("should not be called")
+	}}
+
+	diff := "diff --git a/a b/a\n@@ -0,0 +1 @@\n+x\n"
+	got, err := Condense(context.Background(), p, mustParse(t, diff), ChunkOptions{})
+	if err != nil {
+		t.Fatalf("Condense() error: %v", err)
+	}
+	if got != diff {
+		t.Fatalf("Condense() = %q, want original diff", got)
+	}
+}
+
+func TestCondenseSummarizesChunksInOrder(t *testing.T) {
+	var diff strings.Builder
+	for i := 0; i < 6; i++ {
+		fmt.Fprintf(&diff, "diff --git a/f%d b/f%d\n@@ -1 +1 @@\n+%s\n", i, i, strings.Repeat("x", 800))
+	}
+
+	var inFlight, maxInFlight int32
+	p := &fakeProvider{respond: func(req Request) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+
+		chunk := req.Messages[2].Content
+		return "- summary of " + strings.Fields(chunk)[2], nil
+	}}
+
+	got, err := Condense(context.Background(), p, mustParse(t, diff.String()), ChunkOptions{ContextTokens: 300, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Condense() error: %v", err)
+	}
+
+	if len(p.requests) != 6 {
+		t.Fatalf("expected 6 summarize requests, got %d", len(p.requests))
+	}
+	if maxInFlight > 2 {
+		t.Fatalf("concurrency limit exceeded: %d requests in flight", maxInFlight)
+	}
+	if !strings.HasPrefix(got, summaryHeader) {
+		t.Fatalf("Condense() output missing header: %q", got)
+	}
+	if !strings.Contains(got, "modified f0 (+1 -0)") {
+		t.Fatalf("Condense() output missing file stat: %q", got)
+	}
+	first := strings.Index(got, "summary of a/f0")
+	last := strings.Index(got, "summary of a/f5")
+	if first < 0 || last < 0 || first > last {
+		t.Fatalf("summaries not in chunk order: %q", got)
+	}
+}
+
+func TestCondensePropagatesError(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) {
+		return "", errors.New("boom")
+	}}
+
+	diff := strings.Repeat("diff --git a/f b/f\n@@ -0,0 +1 @@\n+"+strings.Repeat("y", 400)+"\n", 4)
+	if _, err := Condense(context.Background(), p, mustParse(t, diff), ChunkOptions{ContextTokens: 120}); err == nil {
+		t.Fatal("Condense() expected error when a chunk fails")
+	}
+}
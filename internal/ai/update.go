@@ -0,0 +1,97 @@
+package aitool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ledzpl/pcl/internal/adf"
+)
+
+const updateSystemPrompt string = `
+당신은 Jira 이슈를 최신 상태로 유지하는 숙련된 프로젝트 매니저입니다.
+이미 있는 이슈 설명과 새 git diff를 읽고, 설명을 한국어로 간결하게 갱신합니다.
+JSON 유효성과 ADF 적합성을 최우선으로 합니다.
+`
+
+const updatePrompt string = `
+아래에 Jira 이슈 %s의 현재 설명(ADF JSON 또는 Jira wiki markup)과, 그 뒤에 이어진 새 git diff를 줄게.
+두 내용을 합친 새 설명을 ADF 문서로 만들어줘.
+
+규칙:
+- 현재 설명의 구성(제목, 목록 순서)과 사람이 쓴 내용은 최대한 유지하고, diff로 새로 확인된 내용만 보태거나 고쳐.
+- 체크리스트(taskList)가 있으면 diff로 끝난 항목은 state를 "DONE"으로 바꾸고, 새로 생긴 할 일만 "TODO"로 추가해. 기존 localId는 그대로 둬.
+- 새 taskList, taskItem의 attrs.localId는 UUID 형태여야 해.
+- 허용 노드만 사용: "doc, heading, paragraph, bulletList, listItem, taskList, taskItem, codeBlock".
+- 근거는 현재 설명과 diff에 한정하고, 과장/가정 금지. 개인정보/비밀키/토큰/내부 URL 노출 금지.
+
+출력:
+- 오직 ADF 문서 JSON만 출력(추가 설명, 코드펜스, 주석 금지).
+- 최상위는 {"type": "doc", "version": 1, "content": [...]} 형태.`
+
+// UpdateOptions는 MergeDescription 설정이다.
+type UpdateOptions struct {
+	// Key는 갱신할 이슈 키다. 프롬프트에서 이슈를 가리키는 데만 쓴다.
+	Key string
+	// MaxRepairs는 검증 오류를 되돌려 주며 다시 요청하는 최대 횟수다.
+	MaxRepairs int
+}
+
+// MergeDescription은 이슈의 현재 설명과 새 diff를 합친 설명을 ADF 문서로 생성한다.
+// current는 ADF JSON이나 wiki markup 원문이다. 응답이 올바른 ADF가 아니면 opts.MaxRepairs번까지 다시 요청한다.
+func MergeDescription(ctx context.Context, p Provider, current, diff string, opts UpdateOptions) (*adf.Node, error) {
+	if strings.TrimSpace(current) == "" {
+		current = "(설명 없음)"
+	}
+	messages := []Message{
+		{Role: RoleSystem, Content: updateSystemPrompt},
+		{Role: RoleUser, Content: fmt.Sprintf(updatePrompt, opts.Key)},
+		{Role: RoleUser, Content: "현재 설명:\n" + current},
+		{Role: RoleUser, Content: diff},
+	}
+	return generateDoc(ctx, p, messages, opts.MaxRepairs)
+}
+
+// generateDoc은 ADF 문서 하나를 응답으로 받는다. 파싱이나 검증에 실패하면 오류를 대화에 덧붙여
+// maxRepairs번까지 다시 요청한다.
+func generateDoc(ctx context.Context, p Provider, messages []Message, maxRepairs int) (*adf.Node, error) {
+	var lastErr error
+	attempts := 0
+	for attempts <= max(maxRepairs, 0) {
+		attempts++
+		response, err := p.Complete(ctx, Request{Messages: messages})
+		if err != nil {
+			return nil, err
+		}
+
+		doc, problems := parseDoc(response)
+		if len(problems) == 0 {
+			return doc, nil
+		}
+
+		lines := make([]string, len(problems))
+		for i, e := range problems {
+			lines[i] = "- " + e.Error()
+		}
+		lastErr = errors.New(strings.Join(lines, "; "))
+		messages = append(messages,
+			Message{Role: RoleAssistant, Content: response},
+			Message{Role: RoleUser, Content: fmt.Sprintf(repairPrompt, strings.Join(lines, "\n"))},
+		)
+	}
+	return nil, fmt.Errorf("aitool: no valid ADF document after %d attempts: %w", attempts, lastErr)
+}
+
+// parseDoc은 응답을 ADF 문서로 읽고 검증한다. 문제가 있으면 위치가 드러나는 오류 목록을 반환한다.
+func parseDoc(response string) (*adf.Node, []adf.Error) {
+	var doc adf.Node
+	if err := json.Unmarshal([]byte(StripCodeFence(response)), &doc); err != nil {
+		return nil, []adf.Error{{Message: "response is not a JSON ADF document: " + err.Error()}}
+	}
+	if problems := adf.Validate(&doc); len(problems) > 0 {
+		return nil, problems
+	}
+	return &doc, nil
+}
@@ -0,0 +1,61 @@
+package aitool
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const mergedDoc = `{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"재시도 추가 완료"}]}]}`
+
+func TestMergeDescriptionSendsCurrentDescription(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) { return "```json\n" + mergedDoc + "\n```", nil }}
+
+	doc, err := MergeDescription(context.Background(), p, "h3. 배경", repairDiff, UpdateOptions{Key: "PCL-7", MaxRepairs: 1})
+	if err != nil {
+		t.Fatalf("MergeDescription() error: %v", err)
+	}
+	if doc.Content[0].Content[0].Text != "재시도 추가 완료" {
+		t.Fatalf("MergeDescription() = %+v", doc)
+	}
+
+	messages := p.requests[0].Messages
+	if !strings.Contains(messages[1].Content, "PCL-7") || messages[2].Content != "현재 설명:\nh3. 배경" || messages[3].Content != repairDiff {
+		t.Fatalf("unexpected messages: %+v", messages)
+	}
+}
+
+func TestMergeDescriptionRepairsInvalidDocument(t *testing.T) {
+	responses := []string{
+		`{"type":"doc","version":1,"content":[{"type":"table"}]}`,
+		mergedDoc,
+	}
+	p := &fakeProvider{respond: func(Request) (string, error) {
+		r := responses[0]
+		responses = responses[1:]
+		return r, nil
+	}}
+
+	if _, err := MergeDescription(context.Background(), p, "", repairDiff, UpdateOptions{Key: "PCL-7", MaxRepairs: 1}); err != nil {
+		t.Fatalf("MergeDescription() error: %v", err)
+	}
+	if len(p.requests) != 2 {
+		t.Fatalf("requests = %d, want 2", len(p.requests))
+	}
+	last := p.requests[1].Messages[len(p.requests[1].Messages)-1].Content
+	if !strings.Contains(last, "content[0].type") {
+		t.Fatalf("repair prompt missing problem path:\n%s", last)
+	}
+	if p.requests[0].Messages[2].Content != "현재 설명:\n(설명 없음)" {
+		t.Fatalf("empty description message = %q", p.requests[0].Messages[2].Content)
+	}
+}
+
+func TestMergeDescriptionGivesUp(t *testing.T) {
+	p := &fakeProvider{respond: func(Request) (string, error) { return "설명을 만들 수 없습니다", nil }}
+
+	_, err := MergeDescription(context.Background(), p, "", repairDiff, UpdateOptions{Key: "PCL-7", MaxRepairs: 1})
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Fatalf("MergeDescription() error = %v, want failure after 2 attempts", err)
+	}
+}
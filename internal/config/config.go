@@ -3,7 +3,9 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
 )
 
@@ -13,6 +15,114 @@ type Config struct {
 	JiraHost     string `json:"jira_host"`
 	JiraEmail    string `json:"jira_email"`
 	JiraProject  string `json:"jira_project"`
+	// JiraDeployment는 cloud(기본값) 또는 datacenter다. datacenter는 PAT Bearer 인증과 REST API v2를 사용한다.
+	JiraDeployment string `json:"jira_deployment"`
+	// JiraTimeoutSeconds는 Jira 요청 하나의 제한 시간(초)이다. 0이면 8초다.
+	JiraTimeoutSeconds int `json:"jira_timeout_seconds"`
+	// JiraMaxRetries는 Jira가 429/5xx로 응답할 때 다시 시도하는 횟수다. nil이면 3회다.
+	// 생성·댓글처럼 중복될 수 있는 POST 요청은 429와 503만 다시 시도한다.
+	JiraMaxRetries *int `json:"jira_max_retries"`
+	// JiraMetaCacheHours는 프로젝트 메타데이터(이슈 타입, 필드)를 캐시하는 시간이다. nil이면 24시간, 0이면 캐시하지 않는다.
+	JiraMetaCacheHours *int `json:"jira_meta_cache_hours"`
+	// JiraTransitions는 작업(commit, issue, update, comment)이 끝난 뒤 이슈를 옮길 전환 또는 상태 이름이다.
+	JiraTransitions map[string]string `json:"jira_transitions"`
+
+	// JiraLabels, JiraComponents, JiraFixVersions는 새 이슈에 항상 붙이는 값이다. 모델이 제안한 라벨·컴포넌트와 합친다.
+	JiraLabels      []string `json:"jira_labels"`
+	JiraComponents  []string `json:"jira_components"`
+	JiraFixVersions []string `json:"jira_fix_versions"`
+	// JiraPriority는 모델이 우선순위를 정하지 않았을 때 쓰는 기본 우선순위다.
+	JiraPriority string `json:"jira_priority"`
+	// JiraActiveSprint가 true면 새 이슈를 진행 중인 스프린트에 넣는다.
+	JiraActiveSprint bool `json:"jira_active_sprint"`
+	// JiraBoardID는 스프린트를 찾을 보드다. 0이면 프로젝트의 첫 스크럼 보드를 쓴다.
+	JiraBoardID int `json:"jira_board_id"`
+
+	// AIProvider는 openai(기본값), azure, anthropic, ollama, openai-compatible 중 하나다.
+	AIProvider string `json:"ai_provider"`
+	// AIAPIKey가 비어 있으면 OpenAIAPIKey를 사용한다.
+	AIAPIKey     string `json:"ai_api_key"`
+	AIBaseURL    string `json:"ai_base_url"`
+	AIModel      string `json:"ai_model"`
+	AIAPIVersion string `json:"ai_api_version"`
+
+	// AICommitModel, AIIssueModel은 작업별 모델이다. 비어 있으면 AIModel을 사용한다.
+	AICommitModel string `json:"ai_commit_model"`
+	AIIssueModel  string `json:"ai_issue_model"`
+
+	// 생성 파라미터. 비어 있으면 프로바이더 기본값을 사용한다.
+	AITemperature     *float64 `json:"ai_temperature"`
+	AIReasoningEffort string   `json:"ai_reasoning_effort"`
+	AIMaxTokens       int      `json:"ai_max_tokens"`
+	AISeed            *int64   `json:"ai_seed"`
+
+	// AIContextTokens를 넘는 diff는 조각으로 나눠 요약한 뒤 합친다. 0이면 기본값을 사용한다.
+	AIContextTokens int `json:"ai_context_tokens"`
+	// AIConcurrency는 조각 요약을 동시에 요청하는 최대 개수다. 0이면 기본값을 사용한다.
+	AIConcurrency int `json:"ai_concurrency"`
+	// AIMaxRepairs는 AI 응답이 검증에 실패할 때 다시 요청하는 횟수다. nil이면 2회다.
+	AIMaxRepairs *int `json:"ai_max_repairs"`
+
+	// DiffInclude가 비어 있지 않으면 일치하는 파일만 AI에 보낸다.
+	DiffInclude []string `json:"diff_include"`
+	// DiffExclude와 일치하는 파일은 AI에 보내지 않는다.
+	DiffExclude []string `json:"diff_exclude"`
+	// DiffNoDefaultExcludes가 true면 잠금 파일·벤더·생성 코드 기본 제외 규칙을 끈다.
+	DiffNoDefaultExcludes bool `json:"diff_no_default_excludes"`
+
+	// RedactPatterns는 기본 규칙에 더해 가릴 값의 이름 → 정규식이다.
+	RedactPatterns map[string]string `json:"redact_patterns"`
+	// RedactDisable은 끌 가리기 규칙 이름 목록이다.
+	RedactDisable []string `json:"redact_disable"`
+	// RedactEntropyThreshold는 high-entropy 규칙 기준이다. 0이면 기본값을 쓴다.
+	RedactEntropyThreshold float64 `json:"redact_entropy_threshold"`
+	// RedactBlock이 true면 민감 정보가 발견될 때 AI 호출을 중단한다.
+	RedactBlock bool `json:"redact_block"`
+
+	// CommitIssueKey는 브랜치 이름에서 찾은 이슈 키를 커밋 메시지에 넣는 방식이다 (none, prefix, footer).
+	CommitIssueKey string `json:"commit_issue_key"`
+}
+
+// Jira 배포 형태.
+const (
+	JiraCloud      = "cloud"
+	JiraDataCenter = "datacenter"
+)
+
+// 커밋 메시지에 이슈 키를 넣는 방식.
+const (
+	IssueKeyNone   = "none"
+	IssueKeyPrefix = "prefix"
+	IssueKeyFooter = "footer"
+)
+
+var issueKeyModes = []string{IssueKeyNone, IssueKeyPrefix, IssueKeyFooter}
+
+// 작업별 설정(모델, 전환 등)을 고를 때 사용하는 작업 이름.
+// update와 comment는 모델로 ActionIssue 설정을 쓴다.
+const (
+	ActionCommit  = "commit"
+	ActionIssue   = "issue"
+	ActionUpdate  = "update"
+	ActionComment = "comment"
+)
+
+// TransitionActions는 jira_transitions에 쓸 수 있는 작업 이름이다.
+var TransitionActions = []string{ActionCommit, ActionIssue, ActionUpdate, ActionComment}
+
+var reasoningEfforts = []string{"minimal", "low", "medium", "high"}
+
+// aiProviders는 프로바이더별로 필요한 설정을 나타낸다.
+var aiProviders = map[string]struct {
+	needsKey     bool
+	needsBaseURL bool
+	needsModel   bool
+}{
+	"openai":            {needsKey: true},
+	"azure":             {needsKey: true, needsBaseURL: true, needsModel: true},
+	"anthropic":         {needsKey: true, needsModel: true},
+	"ollama":            {needsModel: true},
+	"openai-compatible": {needsBaseURL: true, needsModel: true},
 }
 
 func Load(path string) (*Config, error) {
@@ -29,30 +139,101 @@ func Load(path string) (*Config, error) {
 	return &cfg, nil
 }
 
+// Provider는 사용할 AI 프로바이더 이름을 반환한다. 설정이 비어 있으면 openai다.
+func (c Config) Provider() string {
+	if isBlank(c.AIProvider) {
+		return "openai"
+	}
+	return strings.ToLower(strings.TrimSpace(c.AIProvider))
+}
+
+// APIKey는 AI 프로바이더에 사용할 키를 반환한다.
+func (c Config) APIKey() string {
+	if !isBlank(c.AIAPIKey) {
+		return c.AIAPIKey
+	}
+	return c.OpenAIAPIKey
+}
+
+// Deployment는 Jira 배포 형태(cloud 또는 datacenter)를 반환한다. 비어 있으면 cloud다.
+// server, data-center는 datacenter로 취급한다. 알 수 없는 값은 소문자로 그대로 돌려준다.
+func (c Config) Deployment() string {
+	switch d := strings.ToLower(strings.TrimSpace(c.JiraDeployment)); d {
+	case "":
+		return JiraCloud
+	case "server", "data-center", "dc":
+		return JiraDataCenter
+	default:
+		return d
+	}
+}
+
+// IssueKeyMode는 커밋 메시지에 이슈 키를 넣는 방식을 반환한다. 비어 있으면 none이다.
+func (c Config) IssueKeyMode() string {
+	if isBlank(c.CommitIssueKey) {
+		return IssueKeyNone
+	}
+	return strings.ToLower(strings.TrimSpace(c.CommitIssueKey))
+}
+
+// TransitionFor는 action이 끝난 뒤 이슈를 옮길 전환 또는 상태 이름을 반환한다. 설정이 없으면 빈 문자열이다.
+func (c Config) TransitionFor(action string) string {
+	return strings.TrimSpace(c.JiraTransitions[action])
+}
+
+// ModelFor는 action에 사용할 모델 이름을 반환한다.
+func (c Config) ModelFor(action string) string {
+	switch action {
+	case ActionCommit:
+		if !isBlank(c.AICommitModel) {
+			return c.AICommitModel
+		}
+	case ActionIssue:
+		if !isBlank(c.AIIssueModel) {
+			return c.AIIssueModel
+		}
+	}
+	return c.AIModel
+}
+
 func (c Config) Validate() error {
 	return c.ValidateForJira()
 }
 
-func (c Config) ValidateForAI() error {
-	if isBlank(c.OpenAIAPIKey) {
-		return fmt.Errorf("config: missing required keys: %s", "openai_api_key")
+// ValidateForAI는 action에 쓸 AI 설정을 검사한다. 모델은 ModelFor(action)만 확인한다.
+func (c Config) ValidateForAI(action string) error {
+	missing, err := c.missingForAI(action)
+	if err != nil {
+		return err
 	}
-	return nil
+	if len(missing) > 0 {
+		return fmt.Errorf("config: missing required keys: %s", strings.Join(missing, ", "))
+	}
+	return c.validateGeneration()
 }
 
 func (c Config) ValidateForJira() error {
-	missing := make([]string, 0, 5)
+	missing, err := c.missingForAI(ActionIssue)
+	if err != nil {
+		return err
+	}
 
-	if isBlank(c.OpenAIAPIKey) {
-		missing = append(missing, "openai_api_key")
+	if err := c.validateGeneration(); err != nil {
+		return err
 	}
+
 	if isBlank(c.JiraAPIKey) {
 		missing = append(missing, "jira_api_key")
 	}
 	if isBlank(c.JiraHost) {
 		missing = append(missing, "jira_host")
 	}
-	if isBlank(c.JiraEmail) {
+	deployment := c.Deployment()
+	if deployment != JiraCloud && deployment != JiraDataCenter {
+		return fmt.Errorf("config: jira_deployment must be %s or %s, got %q", JiraCloud, JiraDataCenter, c.JiraDeployment)
+	}
+	// Data Center는 PAT만으로 인증하므로 이메일이 필요 없다.
+	if deployment == JiraCloud && isBlank(c.JiraEmail) {
 		missing = append(missing, "jira_email")
 	}
 	if isBlank(c.JiraProject) {
@@ -63,6 +244,92 @@ func (c Config) ValidateForJira() error {
 		return fmt.Errorf("config: missing required keys: %s", strings.Join(missing, ", "))
 	}
 
+	if c.JiraTimeoutSeconds < 0 {
+		return fmt.Errorf("config: jira_timeout_seconds must not be negative, got %d", c.JiraTimeoutSeconds)
+	}
+	if c.JiraMaxRetries != nil && *c.JiraMaxRetries < 0 {
+		return fmt.Errorf("config: jira_max_retries must not be negative, got %d", *c.JiraMaxRetries)
+	}
+	if c.JiraMetaCacheHours != nil && *c.JiraMetaCacheHours < 0 {
+		return fmt.Errorf("config: jira_meta_cache_hours must not be negative, got %d", *c.JiraMetaCacheHours)
+	}
+	for _, l := range c.JiraLabels {
+		if isBlank(l) || strings.ContainsAny(l, " \t\r\n") {
+			return fmt.Errorf("config: jira_labels must not be empty or contain spaces, got %q", l)
+		}
+	}
+	if c.JiraBoardID < 0 {
+		return fmt.Errorf("config: jira_board_id must not be negative, got %d", c.JiraBoardID)
+	}
+	for action, name := range c.JiraTransitions {
+		if !slices.Contains(TransitionActions, action) {
+			return fmt.Errorf("config: jira_transitions key must be one of %s, got %q", strings.Join(TransitionActions, ", "), action)
+		}
+		if isBlank(name) {
+			return fmt.Errorf("config: jira_transitions[%q] must not be empty", action)
+		}
+	}
+
+	return nil
+}
+
+func (c Config) missingForAI(action string) ([]string, error) {
+	req, ok := aiProviders[c.Provider()]
+	if !ok {
+		return nil, fmt.Errorf("config: unknown ai_provider %q", c.AIProvider)
+	}
+
+	missing := make([]string, 0, 5)
+	if req.needsKey && isBlank(c.APIKey()) {
+		if c.Provider() == "openai" {
+			missing = append(missing, "openai_api_key")
+		} else {
+			missing = append(missing, "ai_api_key")
+		}
+	}
+	if req.needsBaseURL && isBlank(c.AIBaseURL) {
+		missing = append(missing, "ai_base_url")
+	}
+	if req.needsModel && isBlank(c.ModelFor(action)) {
+		missing = append(missing, "ai_model")
+	}
+	return missing, nil
+}
+
+func (c Config) validateGeneration() error {
+	if !isBlank(c.AIBaseURL) {
+		u, err := url.Parse(c.AIBaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("config: ai_base_url must be an http(s) URL, got %q", c.AIBaseURL)
+		}
+	}
+	if c.AITemperature != nil && (*c.AITemperature < 0 || *c.AITemperature > 2) {
+		return fmt.Errorf("config: ai_temperature must be between 0 and 2, got %v", *c.AITemperature)
+	}
+	if !isBlank(c.AIReasoningEffort) && !slices.Contains(reasoningEfforts, c.AIReasoningEffort) {
+		return fmt.Errorf("config: ai_reasoning_effort must be one of %s, got %q", strings.Join(reasoningEfforts, ", "), c.AIReasoningEffort)
+	}
+	if c.AIMaxTokens < 0 {
+		return fmt.Errorf("config: ai_max_tokens must not be negative, got %d", c.AIMaxTokens)
+	}
+	if c.AIContextTokens < 0 {
+		return fmt.Errorf("config: ai_context_tokens must not be negative, got %d", c.AIContextTokens)
+	}
+	if c.AIConcurrency < 0 {
+		return fmt.Errorf("config: ai_concurrency must not be negative, got %d", c.AIConcurrency)
+	}
+	if c.AISeed != nil && *c.AISeed < 0 {
+		return fmt.Errorf("config: ai_seed must not be negative, got %d", *c.AISeed)
+	}
+	if c.AIMaxRepairs != nil && *c.AIMaxRepairs < 0 {
+		return fmt.Errorf("config: ai_max_repairs must not be negative, got %d", *c.AIMaxRepairs)
+	}
+	if c.RedactEntropyThreshold < 0 {
+		return fmt.Errorf("config: redact_entropy_threshold must not be negative, got %v", c.RedactEntropyThreshold)
+	}
+	if !slices.Contains(issueKeyModes, c.IssueKeyMode()) {
+		return fmt.Errorf("config: commit_issue_key must be one of %s, got %q", strings.Join(issueKeyModes, ", "), c.CommitIssueKey)
+	}
 	return nil
 }
 
@@ -6,14 +6,22 @@ func TestValidateForAI(t *testing.T) {
 	t.Parallel()
 
 	cfg := Config{OpenAIAPIKey: "token"}
-	if err := cfg.ValidateForAI(); err != nil {
+	if err := cfg.ValidateForAI(ActionCommit); err != nil {
 		t.Fatalf("ValidateForAI() unexpected error: %v", err)
 	}
 
 	cfg = Config{}
-	if err := cfg.ValidateForAI(); err == nil {
+	if err := cfg.ValidateForAI(ActionCommit); err == nil {
 		t.Fatal("ValidateForAI() expected error when openai_api_key is empty, got nil")
 	}
+
+	commitOnly := Config{AIProvider: "ollama", AICommitModel: "llama3"}
+	if err := commitOnly.ValidateForAI(ActionCommit); err != nil {
+		t.Fatalf("ValidateForAI(commit) unexpected error with only ai_commit_model: %v", err)
+	}
+	if err := commitOnly.ValidateForAI(ActionIssue); err == nil {
+		t.Fatal("ValidateForAI(issue) expected error when no model resolves for issues")
+	}
 }
 
 func TestValidateForJira(t *testing.T) {
@@ -41,4 +49,152 @@ func TestValidateForJira(t *testing.T) {
 	if err := missing.ValidateForJira(); err == nil {
 		t.Fatal("ValidateForJira() expected error when required fields are missing, got nil")
 	}
+
+	dataCenter := missing
+	dataCenter.JiraDeployment = "server"
+	if err := dataCenter.ValidateForJira(); err != nil {
+		t.Fatalf("ValidateForJira() should not require jira_email for Data Center: %v", err)
+	}
+	if dataCenter.Deployment() != JiraDataCenter {
+		t.Fatalf("Deployment() = %q, want %q", dataCenter.Deployment(), JiraDataCenter)
+	}
+
+	unknown := valid
+	unknown.JiraDeployment = "mainframe"
+	if err := unknown.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for unknown jira_deployment")
+	}
+
+	negativeTimeout := valid
+	negativeTimeout.JiraTimeoutSeconds = -1
+	if err := negativeTimeout.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for negative jira_timeout_seconds")
+	}
+
+	retries := -1
+	negativeRetries := valid
+	negativeRetries.JiraMaxRetries = &retries
+	if err := negativeRetries.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for negative jira_max_retries")
+	}
+
+	cacheHours := -1
+	negativeCache := valid
+	negativeCache.JiraMetaCacheHours = &cacheHours
+	if err := negativeCache.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for negative jira_meta_cache_hours")
+	}
+
+	transitions := valid
+	transitions.JiraTransitions = map[string]string{ActionCommit: "In Progress", ActionComment: " In Review "}
+	if err := transitions.ValidateForJira(); err != nil {
+		t.Fatalf("ValidateForJira() unexpected error for jira_transitions: %v", err)
+	}
+	if got := transitions.TransitionFor(ActionComment); got != "In Review" {
+		t.Fatalf("TransitionFor(comment) = %q, want In Review", got)
+	}
+	if got := transitions.TransitionFor(ActionIssue); got != "" {
+		t.Fatalf("TransitionFor(issue) = %q, want empty", got)
+	}
+
+	badLabel := valid
+	badLabel.JiraLabels = []string{"backend", "needs review"}
+	if err := badLabel.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for jira_labels with spaces")
+	}
+	negativeBoard := valid
+	negativeBoard.JiraBoardID = -1
+	if err := negativeBoard.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for negative jira_board_id")
+	}
+
+	unknownAction := valid
+	unknownAction.JiraTransitions = map[string]string{"push": "In Review"}
+	if err := unknownAction.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for unknown jira_transitions action")
+	}
+	blankTransition := valid
+	blankTransition.JiraTransitions = map[string]string{ActionCommit: " "}
+	if err := blankTransition.ValidateForJira(); err == nil {
+		t.Fatal("ValidateForJira() expected error for empty jira_transitions value")
+	}
+}
+
+func TestValidateForAIProviders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"ollamaWithoutKey", Config{AIProvider: "ollama", AIModel: "llama3"}, false},
+		{"ollamaWithoutModel", Config{AIProvider: "ollama"}, true},
+		{"anthropicWithKey", Config{AIProvider: "anthropic", AIAPIKey: "key", AIModel: "claude"}, false},
+		{"anthropicFallsBackToOpenAIKey", Config{AIProvider: "anthropic", OpenAIAPIKey: "key", AIModel: "claude"}, false},
+		{"azureWithoutBaseURL", Config{AIProvider: "azure", AIAPIKey: "key", AIModel: "deploy"}, true},
+		{"compatibleWithBaseURL", Config{AIProvider: "openai-compatible", AIBaseURL: "http://localhost:8080/v1", AIModel: "local"}, false},
+		{"unknownProvider", Config{AIProvider: "bard", AIAPIKey: "key"}, true},
+		{"ollamaWithCommitModelOnly", Config{AIProvider: "ollama", AICommitModel: "llama3"}, false},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			err := caseData.cfg.ValidateForAI(ActionCommit)
+			if (err != nil) != caseData.wantErr {
+				t.Fatalf("ValidateForAI() error = %v, wantErr %v", err, caseData.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateForAIGenerationParameters(t *testing.T) {
+	t.Parallel()
+
+	low, high := 0.3, 2.5
+	negative := int64(-1)
+	negativeInt := -1
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"valid", Config{OpenAIAPIKey: "k", AITemperature: &low, AIReasoningEffort: "low", AIMaxTokens: 1024, AIBaseURL: "https://gateway.internal/v1"}, false},
+		{"temperatureTooHigh", Config{OpenAIAPIKey: "k", AITemperature: &high}, true},
+		{"unknownEffort", Config{OpenAIAPIKey: "k", AIReasoningEffort: "extreme"}, true},
+		{"negativeMaxTokens", Config{OpenAIAPIKey: "k", AIMaxTokens: -1}, true},
+		{"negativeSeed", Config{OpenAIAPIKey: "k", AISeed: &negative}, true},
+		{"negativeContextTokens", Config{OpenAIAPIKey: "k", AIContextTokens: -1}, true},
+		{"negativeConcurrency", Config{OpenAIAPIKey: "k", AIConcurrency: -2}, true},
+		{"negativeMaxRepairs", Config{OpenAIAPIKey: "k", AIMaxRepairs: &negativeInt}, true},
+		{"negativeRedactEntropyThreshold", Config{OpenAIAPIKey: "k", RedactEntropyThreshold: -1}, true},
+		{"baseURLWithoutScheme", Config{OpenAIAPIKey: "k", AIBaseURL: "gateway.internal"}, true},
+		{"commitIssueKeyFooter", Config{OpenAIAPIKey: "k", CommitIssueKey: "Footer"}, false},
+		{"unknownCommitIssueKey", Config{OpenAIAPIKey: "k", CommitIssueKey: "suffix"}, true},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			err := caseData.cfg.ValidateForAI(ActionCommit)
+			if (err != nil) != caseData.wantErr {
+				t.Fatalf("ValidateForAI() error = %v, wantErr %v", err, caseData.wantErr)
+			}
+		})
+	}
+}
+
+func TestModelFor(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{AIModel: "gpt-5", AICommitModel: "gpt-5-mini"}
+
+	if got := cfg.ModelFor(ActionCommit); got != "gpt-5-mini" {
+		t.Fatalf("ModelFor(commit) = %q, want gpt-5-mini", got)
+	}
+	if got := cfg.ModelFor(ActionIssue); got != "gpt-5" {
+		t.Fatalf("ModelFor(issue) = %q, want fallback gpt-5", got)
+	}
 }
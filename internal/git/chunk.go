@@ -0,0 +1,76 @@
+package gittool
+
+import "strings"
+
+// Chunks는 diff를 size 기준으로 limit을 넘지 않는 조각들로 나눈다.
+// 파일 경계를 우선으로 묶고, 한 파일이 limit을 넘으면 헌크 단위로,
+// 한 헌크가 limit을 넘으면 줄 단위로 나눈다. 헌크 조각에는 파일 헤더를 다시 붙인다.
+func (p *Patch) Chunks(limit int, size func(string) int) []string {
+	if p.Empty() {
+		return nil
+	}
+	whole := p.String()
+	if limit <= 0 || size(whole) <= limit {
+		return []string{whole}
+	}
+
+	var pieces []string
+	for _, f := range p.Files {
+		if text := f.String(); size(text) <= limit {
+			pieces = append(pieces, text)
+			continue
+		}
+		pieces = append(pieces, f.chunks(limit, size)...)
+	}
+
+	return pack(pieces, limit, size)
+}
+
+// chunks는 한 파일 diff를 헤더를 유지한 채 헌크 단위 조각으로 나눈다.
+func (f FileDiff) chunks(limit int, size func(string) int) []string {
+	header := FileDiff{Header: f.Header}.String()
+	if len(f.Hunks) == 0 {
+		return ChunkText(header, limit, size)
+	}
+
+	budget := limit - size(header)
+	var out []string
+	for _, h := range f.Hunks {
+		text := h.String()
+		if size(text) <= budget {
+			out = append(out, header+text)
+			continue
+		}
+		for _, part := range ChunkText(text, budget, size) {
+			out = append(out, header+part)
+		}
+	}
+	return out
+}
+
+// ChunkText는 text를 줄 경계에서 limit 이하 조각으로 나눈다. 한 줄이 limit을 넘으면 그대로 둔다.
+func ChunkText(text string, limit int, size func(string) int) []string {
+	return pack(strings.SplitAfter(text, "\n"), limit, size)
+}
+
+// pack은 순서를 유지하며 인접한 조각들을 limit 이하로 합친다.
+func pack(pieces []string, limit int, size func(string) int) []string {
+	var (
+		out     []string
+		current strings.Builder
+	)
+	for _, p := range pieces {
+		if p == "" {
+			continue
+		}
+		if current.Len() > 0 && size(current.String()+p) > limit {
+			out = append(out, current.String())
+			current.Reset()
+		}
+		current.WriteString(p)
+	}
+	if current.Len() > 0 {
+		out = append(out, current.String())
+	}
+	return out
+}
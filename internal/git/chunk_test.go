@@ -0,0 +1,77 @@
+package gittool
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleDiff = `diff --git a/a.go b/a.go
+index 1..2 100644
+--- a/a.go
++++ b/a.go
+@@ -1 +1 @@
+-old a
++new a
+diff --git a/b.go b/b.go
+index 3..4 100644
+--- a/b.go
++++ b/b.go
+@@ -1 +1 @@
+-old b1
++new b1
+@@ -10 +10 @@
+-old b2
++new b2
+`
+
+func byteLen(s string) int { return len(s) }
+
+func mustParse(t *testing.T, raw string) *Patch {
+	t.Helper()
+
+	p, err := ParsePatch(raw)
+	if err != nil {
+		t.Fatalf("ParsePatch() error: %v", err)
+	}
+	return p
+}
+
+func TestChunksFitInOneChunk(t *testing.T) {
+	chunks := mustParse(t, sampleDiff).Chunks(len(sampleDiff), byteLen)
+	if len(chunks) != 1 || chunks[0] != sampleDiff {
+		t.Fatalf("Chunks() = %q, want the whole diff", chunks)
+	}
+}
+
+func TestChunksSplitFilesAndHunks(t *testing.T) {
+	patch := mustParse(t, sampleDiff)
+	limit := len(patch.Files[0].String()) + 5
+
+	chunks := patch.Chunks(limit, byteLen)
+	if len(chunks) != 3 {
+		t.Fatalf("Chunks() returned %d chunks, want 3: %q", len(chunks), chunks)
+	}
+
+	for i, c := range chunks {
+		if len(c) > limit {
+			t.Errorf("chunk %d has size %d, exceeds limit %d", i, len(c), limit)
+		}
+		if !strings.HasPrefix(c, "diff --git ") {
+			t.Errorf("chunk %d lost its file header: %q", i, c)
+		}
+	}
+	if !strings.Contains(chunks[1], "+new b1") || !strings.Contains(chunks[2], "+new b2") {
+		t.Fatalf("hunks not split in order: %q", chunks)
+	}
+}
+
+func TestChunkText(t *testing.T) {
+	text := "line one\nline two\nline three\n"
+	chunks := ChunkText(text, 10, byteLen)
+	if strings.Join(chunks, "") != text {
+		t.Fatalf("ChunkText() lost content: %q", chunks)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("ChunkText() returned %d chunks, want 3", len(chunks))
+	}
+}
@@ -0,0 +1,251 @@
+package gittool
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFile은 저장소 루트에서 읽는 diff 제외 규칙 파일 이름이다. 형식은 .gitignore와 같다.
+const IgnoreFile = ".pclignore"
+
+// DefaultExcludes는 토큰만 차지하는 잠금 파일, 벤더 디렉터리, 스냅샷, 생성 코드 패턴이다.
+var DefaultExcludes = []string{
+	"go.sum",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"Cargo.lock",
+	"Gemfile.lock",
+	"poetry.lock",
+	"composer.lock",
+	"vendor/",
+	"node_modules/",
+	"__snapshots__/",
+	"*.snap",
+	"*.min.js",
+	"*.pb.go",
+	"*_gen.go",
+	"*.generated.*",
+}
+
+var generatedMarker = regexp.MustCompile(`^[+-].*(Code generated .* DO NOT EDIT|@generated)`)
+
+// Filter는 diff에서 뺄 파일을 정하는 규칙이다.
+type Filter struct {
+	// Include가 비어 있지 않으면 하나라도 일치하는 파일만 남긴다.
+	Include []string
+	// Exclude와 일치하는 파일은 뺀다.
+	Exclude []string
+	// NoDefaults가 true면 DefaultExcludes와 생성 코드 감지를 적용하지 않는다.
+	NoDefaults bool
+}
+
+// Excluded는 diff에서 빠진 파일과 그 이유다.
+type Excluded struct {
+	Path   string
+	Reason string
+}
+
+// Apply는 저장소 루트의 .pclignore와 linguist-generated 속성을 함께 적용해
+// 남은 파일만 담은 새 Patch와 제외 목록을 반환한다. 현재 디렉터리는 저장소 안이어야 한다.
+func (f Filter) Apply(p *Patch) (*Patch, []Excluded, error) {
+	if p.Empty() {
+		return p, nil, nil
+	}
+
+	root, err := runGit("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, nil, fmt.Errorf("gittool: locate repository root: %w", err)
+	}
+
+	ignore, err := readIgnoreFile(filepath.Join(root, IgnoreFile))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	generated, err := generatedPaths(root, p)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := &Patch{}
+	var excluded []Excluded
+	for _, file := range p.Files {
+		if reason := f.reason(file, ignore, generated); reason != "" {
+			excluded = append(excluded, Excluded{Path: file.Path(), Reason: reason})
+			continue
+		}
+		out.Files = append(out.Files, file)
+	}
+	return out, excluded, nil
+}
+
+// reason은 file을 뺄 이유를 반환한다. 남겨야 하면 빈 문자열이다.
+func (f Filter) reason(file FileDiff, ignore []string, generated map[string]bool) string {
+	p := file.Path()
+
+	if len(f.Include) > 0 && matchAny(f.Include, p) == "" {
+		return "include 패턴과 일치하지 않음"
+	}
+	if pattern := matchAny(f.Exclude, p); pattern != "" {
+		return "exclude 패턴 " + pattern
+	}
+	if pattern := matchIgnore(ignore, p); pattern != "" {
+		return IgnoreFile + " 패턴 " + pattern
+	}
+	if generated[p] {
+		return "linguist-generated 속성"
+	}
+	if f.NoDefaults {
+		return ""
+	}
+	if pattern := matchAny(DefaultExcludes, p); pattern != "" {
+		return "기본 제외 패턴 " + pattern
+	}
+	if isGeneratedCode(file) {
+		return "생성된 코드 표시(DO NOT EDIT)"
+	}
+	return ""
+}
+
+func isGeneratedCode(file FileDiff) bool {
+	for _, h := range file.Hunks {
+		for _, line := range h.Lines {
+			if generatedMarker.MatchString(line) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// generatedPaths는 linguist-generated 속성이 설정된 파일 경로를 반환한다.
+func generatedPaths(root string, p *Patch) (map[string]bool, error) {
+	args := []string{"-C", root, "check-attr", "-z", "linguist-generated", "--"}
+	for _, f := range p.Files {
+		args = append(args, f.Path())
+	}
+
+	out, err := runGit(args...)
+	if err != nil {
+		return nil, fmt.Errorf("gittool: check linguist-generated attribute: %w", err)
+	}
+
+	result := map[string]bool{}
+	fields := strings.Split(out, "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if v := fields[i+2]; v == "set" || v == "true" {
+			result[fields[i]] = true
+		}
+	}
+	return result, nil
+}
+
+func readIgnoreFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gittool: read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("gittool: read %s: %w", path, err)
+	}
+	return patterns, nil
+}
+
+// matchIgnore는 .gitignore처럼 마지막으로 일치한 규칙을 따른다. "!" 규칙은 다시 포함시킨다.
+func matchIgnore(patterns []string, p string) string {
+	matched := ""
+	for _, pattern := range patterns {
+		if negated, ok := strings.CutPrefix(pattern, "!"); ok {
+			if MatchGlob(negated, p) {
+				matched = ""
+			}
+			continue
+		}
+		if MatchGlob(pattern, p) {
+			matched = pattern
+		}
+	}
+	return matched
+}
+
+func matchAny(patterns []string, p string) string {
+	for _, pattern := range patterns {
+		if MatchGlob(pattern, p) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// MatchGlob은 .gitignore 규칙과 비슷하게 pattern이 저장소 상대 경로 p와 일치하는지 확인한다.
+//   - "/"가 없는 패턴은 어느 깊이의 파일/디렉터리 이름과도 일치한다 (예: "*.snap", "vendor").
+//   - "/"로 끝나는 패턴은 디렉터리에만 일치한다 (예: "vendor/").
+//   - "/"가 포함된 패턴은 저장소 루트 기준이다. "**"는 0개 이상의 디렉터리와 일치한다.
+func MatchGlob(pattern, p string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return false
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+
+	pat := strings.Split(pattern, "/")
+	segs := strings.Split(path.Clean(p), "/")
+
+	// 파일 자체 또는 그 상위 디렉터리 중 하나와 일치하면 된다.
+	last := len(segs)
+	if dirOnly {
+		last--
+	}
+	for i := 1; i <= last; i++ {
+		if matchSegments(pat, segs[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pat, segs []string) bool {
+	if len(pat) == 0 {
+		return len(segs) == 0
+	}
+	if pat[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pat[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	ok, err := path.Match(pat[0], segs[0])
+	return err == nil && ok && matchSegments(pat[1:], segs[1:])
+}
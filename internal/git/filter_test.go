@@ -0,0 +1,110 @@
+package gittool
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"go.sum", "go.sum", true},
+		{"go.sum", "tools/go.sum", true},
+		{"*.snap", "ui/__snapshots__/button.test.js.snap", true},
+		{"vendor/", "vendor/github.com/x/y.go", true},
+		{"vendor/", "pkg/vendor/a.go", true},
+		{"vendor/", "vendor", false},
+		{"docs", "docs/guide.md", true},
+		{"/docs", "api/docs/guide.md", false},
+		{"internal/**/*.go", "internal/ai/aitool.go", true},
+		{"internal/**/*.go", "internal/aitool.go", true},
+		{"internal/*.go", "internal/ai/aitool.go", false},
+		{"*.go", "main.go", true},
+		{"*.go", "README.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+
+	writeFile(t, filepath.Join(repoDir, ".pclignore"), "# 문서는 제외\ndocs/\n!docs/keep.md\n")
+	writeFile(t, filepath.Join(repoDir, ".gitattributes"), "api.gen linguist-generated\n")
+
+	raw := `diff --git a/main.go b/main.go
+@@ -1 +1 @@
+-a
++b
+diff --git a/go.sum b/go.sum
+@@ -1 +1 @@
+-a
++b
+diff --git a/docs/guide.md b/docs/guide.md
+@@ -1 +1 @@
+-a
++b
+diff --git a/docs/keep.md b/docs/keep.md
+@@ -1 +1 @@
+-a
++b
+diff --git a/api.gen b/api.gen
+@@ -1 +1 @@
+-a
++b
+diff --git a/mock.go b/mock.go
+@@ -0,0 +1 @@
++// Code generated by mockgen. DO NOT EDIT.
+diff --git a/tmp/debug.log b/tmp/debug.log
+@@ -0,0 +1 @@
++x
+`
+
+	withWorkdir(t, repoDir, func() {
+		filtered, excluded, err := Filter{Exclude: []string{"*.log"}}.Apply(mustParse(t, raw))
+		if err != nil {
+			t.Fatalf("Apply() error: %v", err)
+		}
+
+		var kept []string
+		for _, f := range filtered.Files {
+			kept = append(kept, f.Path())
+		}
+		if len(kept) != 2 || kept[0] != "main.go" || kept[1] != "docs/keep.md" {
+			t.Fatalf("kept = %v, want [main.go docs/keep.md]", kept)
+		}
+
+		reasons := map[string]string{}
+		for _, e := range excluded {
+			reasons[e.Path] = e.Reason
+		}
+		want := map[string]string{
+			"go.sum":        "기본 제외 패턴 go.sum",
+			"docs/guide.md": ".pclignore 패턴 docs/",
+			"api.gen":       "linguist-generated 속성",
+			"mock.go":       "생성된 코드 표시(DO NOT EDIT)",
+			"tmp/debug.log": "exclude 패턴 *.log",
+		}
+		for path, reason := range want {
+			if reasons[path] != reason {
+				t.Errorf("reason for %s = %q, want %q", path, reasons[path], reason)
+			}
+		}
+
+		filtered, _, err = Filter{Include: []string{"docs/"}, NoDefaults: true}.Apply(mustParse(t, raw))
+		if err != nil {
+			t.Fatalf("Apply() error: %v", err)
+		}
+		if len(filtered.Files) != 1 || filtered.Files[0].Path() != "docs/keep.md" {
+			t.Fatalf("include filter kept %+v", filtered.Files)
+		}
+	})
+}
@@ -3,8 +3,10 @@ package gittool
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"log"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	git "github.com/go-git/go-git/v5"
@@ -36,23 +38,81 @@ func GetBranches() []string {
 	return result
 }
 
-func Diff(src string) string {
+// Source는 diff를 추출할 범위를 나타낸다.
+type Source string
 
-	base := detectUpstream(src)
+const (
+	// SourceWorkingTree는 기준 브랜치의 fork point부터 현재 작업 트리까지의 변경이다.
+	SourceWorkingTree Source = "worktree"
+	// SourceStaged는 인덱스에 스테이징된 변경(--cached)만 포함한다.
+	SourceStaged Source = "staged"
+	// SourceUnstaged는 아직 스테이징되지 않은 작업 트리 변경만 포함한다.
+	SourceUnstaged Source = "unstaged"
+	// SourceCommitted는 기준 브랜치의 fork point부터 HEAD까지 커밋된 변경만 포함한다.
+	SourceCommitted Source = "committed"
+)
+
+// Sources는 지원하는 diff 범위 목록이다.
+var Sources = []Source{SourceWorkingTree, SourceStaged, SourceUnstaged, SourceCommitted}
 
-	diff, err := runGit("diff",
+// ParseSource는 문자열을 Source로 변환한다.
+func ParseSource(s string) (Source, error) {
+	for _, src := range Sources {
+		if string(src) == s {
+			return src, nil
+		}
+	}
+	return "", fmt.Errorf("gittool: unknown diff source %q", s)
+}
+
+// NeedsBase는 해당 범위가 기준 브랜치를 필요로 하는지 알려준다.
+func (s Source) NeedsBase() bool {
+	return s == SourceWorkingTree || s == SourceCommitted
+}
+
+// LoadPatch는 source 범위의 diff를 파일/헌크 구조로 파싱해 반환한다.
+func LoadPatch(source Source, base string) (*Patch, error) {
+	diff, err := diffText(source, base)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatch(diff)
+}
+
+// LoadRangePatch는 from 커밋부터 to 커밋까지의 diff를 파일/헌크 구조로 파싱해 반환한다.
+func LoadRangePatch(from, to string) (*Patch, error) {
+	diff, err := runGit(append(diffArgs(), from, to)...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatch(diff)
+}
+
+func diffArgs() []string {
+	return []string{"diff",
 		"--no-color",
 		"--no-ext-diff",
 		"-U0",
 		"-M",
-		"-w",
-		base)
+		"-w"}
+}
 
-	if err != nil {
-		log.Fatal(err)
+func diffText(source Source, base string) (string, error) {
+	args := diffArgs()
+
+	switch source {
+	case SourceWorkingTree:
+		args = append(args, detectUpstream(base))
+	case SourceStaged:
+		args = append(args, "--cached")
+	case SourceUnstaged:
+	case SourceCommitted:
+		args = append(args, detectUpstream(base), "HEAD")
+	default:
+		return "", fmt.Errorf("gittool: unsupported diff source %q", source)
 	}
 
-	return diff
+	return runGit(args...)
 }
 
 func detectUpstream(src string) string {
@@ -62,8 +122,77 @@ func detectUpstream(src string) string {
 	return "HEAD"
 }
 
+// HasStagedChanges는 인덱스에 커밋할 변경 사항이 있는지 확인한다.
+func HasStagedChanges() (bool, error) {
+	out, err := runGit("diff", "--cached", "--name-only")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// Commit은 스테이징된 변경 사항을 주어진 메시지로 커밋한다.
+func Commit(message string) error {
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("gittool: commit message is empty")
+	}
+	if _, err := runGitInput(strings.NewReader(message), "commit", "--file=-"); err != nil {
+		return fmt.Errorf("gittool: commit failed: %w", err)
+	}
+	return nil
+}
+
+// HookPath는 name 훅 파일의 절대 경로를 반환한다. core.hooksPath 설정을 따른다.
+func HookPath(name string) (string, error) {
+	dir, err := GitPath("hooks")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// GitPath는 .git 디렉터리 안의 name 경로를 절대 경로로 반환한다 (git rev-parse --git-path).
+// worktree에서도 올바른 위치를 가리킨다.
+func GitPath(name string) (string, error) {
+	p, err := runGit("rev-parse", "--git-path", name)
+	if err != nil {
+		return "", fmt.Errorf("gittool: locate %s: %w", name, err)
+	}
+
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", fmt.Errorf("gittool: resolve %s path: %w", name, err)
+	}
+	return abs, nil
+}
+
+// HeadCommit은 HEAD 커밋의 전체 SHA를 반환한다.
+func HeadCommit() (string, error) {
+	sha, err := runGit("rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("gittool: resolve HEAD: %w", err)
+	}
+	return sha, nil
+}
+
+// IsAncestor는 rev 커밋이 HEAD의 조상(또는 HEAD 자신)인지 확인한다. rev가 없거나 오류면 false다.
+func IsAncestor(rev string) bool {
+	_, err := runGit("merge-base", "--is-ancestor", rev, "HEAD")
+	return err == nil
+}
+
+// ForkPoint는 base 브랜치에서 갈라진 커밋을 반환한다. 찾지 못하면 HEAD다.
+func ForkPoint(base string) string {
+	return detectUpstream(base)
+}
+
 func runGit(args ...string) (string, error) {
+	return runGitInput(nil, args...)
+}
+
+func runGitInput(stdin io.Reader, args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
+	cmd.Stdin = stdin
 	var out, errb bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &errb
@@ -39,12 +39,15 @@ func TestDiffReturnsChangesFromForkPoint(t *testing.T) {
 	runGitCmd(t, repoDir, "commit", "-m", "replace content")
 
 	withWorkdir(t, repoDir, func() {
-		diff := Diff("main")
+		diff, err := diffText(SourceWorkingTree, "main")
+		if err != nil {
+			t.Fatalf("diffText() error: %v", err)
+		}
 		if diff == "" {
-			t.Fatal("Diff() returned empty string")
+			t.Fatal("diffText() returned empty string")
 		}
 		if !strings.Contains(diff, "+beta") || !strings.Contains(diff, "-alpha") {
-			t.Fatalf("Diff() missing expected hunks: %s", diff)
+			t.Fatalf("diffText() missing expected hunks: %s", diff)
 		}
 	})
 }
@@ -119,9 +122,177 @@ func TestDiffNoChangesReturnsEmpty(t *testing.T) {
 	t.Cleanup(cleanup)
 
 	withWorkdir(t, repoDir, func() {
-		diff := Diff("main")
-		if diff != "" {
-			t.Fatalf("Diff() expected empty string, got %q", diff)
+		patch, err := LoadPatch(SourceWorkingTree, "main")
+		if err != nil {
+			t.Fatalf("LoadPatch() error: %v", err)
+		}
+		if !patch.Empty() {
+			t.Fatalf("LoadPatch() expected empty patch, got %+v", patch)
+		}
+	})
+}
+
+func TestHasStagedChanges(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+
+	withWorkdir(t, repoDir, func() {
+		staged, err := HasStagedChanges()
+		if err != nil {
+			t.Fatalf("HasStagedChanges() error: %v", err)
+		}
+		if staged {
+			t.Fatal("HasStagedChanges() = true on clean index")
+		}
+
+		if err := os.WriteFile("readme.txt", []byte("gamma\n"), 0o644); err != nil {
+			t.Fatalf("write content: %v", err)
+		}
+		runGitCmd(t, repoDir, "add", "readme.txt")
+
+		staged, err = HasStagedChanges()
+		if err != nil {
+			t.Fatalf("HasStagedChanges() error: %v", err)
+		}
+		if !staged {
+			t.Fatal("HasStagedChanges() = false after git add")
+		}
+	})
+}
+
+func TestCommitUsesMessage(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+	setTestIdentity(t)
+
+	withWorkdir(t, repoDir, func() {
+		if err := os.WriteFile("readme.txt", []byte("gamma\n"), 0o644); err != nil {
+			t.Fatalf("write content: %v", err)
+		}
+		runGitCmd(t, repoDir, "add", "readme.txt")
+
+		message := "feat: 감마 추가\n\n- 본문"
+		if err := Commit(message); err != nil {
+			t.Fatalf("Commit() error: %v", err)
+		}
+
+		got, err := runGit("log", "-1", "--format=%B")
+		if err != nil {
+			t.Fatalf("git log: %v", err)
+		}
+		if got != message {
+			t.Fatalf("commit message = %q, want %q", got, message)
+		}
+	})
+}
+
+func TestCommitRejectsEmptyMessage(t *testing.T) {
+	if err := Commit("  \n"); err == nil {
+		t.Fatal("Commit() expected error for blank message")
+	}
+}
+
+func setTestIdentity(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+}
+
+func TestDiffFromSources(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+
+	runGitCmd(t, repoDir, "checkout", "-b", "feature")
+
+	writeFile(t, filepath.Join(repoDir, "committed.txt"), "committed\n")
+	runGitCmd(t, repoDir, "add", "committed.txt")
+	runGitCmd(t, repoDir, "commit", "-m", "add committed file")
+
+	writeFile(t, filepath.Join(repoDir, "staged.txt"), "staged\n")
+	runGitCmd(t, repoDir, "add", "staged.txt")
+
+	writeFile(t, filepath.Join(repoDir, "readme.txt"), "unstaged\n")
+
+	tests := []struct {
+		source  Source
+		want    []string
+		notWant []string
+	}{
+		{SourceWorkingTree, []string{"+committed", "+staged", "+unstaged"}, nil},
+		{SourceStaged, []string{"+staged"}, []string{"+committed", "+unstaged"}},
+		{SourceUnstaged, []string{"+unstaged"}, []string{"+committed", "+staged"}},
+		{SourceCommitted, []string{"+committed"}, []string{"+staged", "+unstaged"}},
+	}
+
+	withWorkdir(t, repoDir, func() {
+		for _, tt := range tests {
+			diff, err := diffText(tt.source, "main")
+			if err != nil {
+				t.Errorf("diffText(%s) error: %v", tt.source, err)
+				continue
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(diff, w) {
+					t.Errorf("diffText(%s) missing %q:\n%s", tt.source, w, diff)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if strings.Contains(diff, nw) {
+					t.Errorf("diffText(%s) unexpectedly contains %q:\n%s", tt.source, nw, diff)
+				}
+			}
+		}
+		if _, err := LoadPatch(Source("everything"), "main"); err == nil {
+			t.Error("LoadPatch() expected error for unsupported source")
+		}
+	})
+}
+
+func TestParseSource(t *testing.T) {
+	for _, src := range Sources {
+		got, err := ParseSource(string(src))
+		if err != nil || got != src {
+			t.Fatalf("ParseSource(%q) = %q, %v", src, got, err)
+		}
+	}
+
+	if _, err := ParseSource("everything"); err == nil {
+		t.Fatal("ParseSource() expected error for unknown source")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestHookPathRespectsHooksPath(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+
+	withWorkdir(t, repoDir, func() {
+		got, err := HookPath("prepare-commit-msg")
+		if err != nil {
+			t.Fatalf("HookPath() error: %v", err)
+		}
+		if want := filepath.Join(".git", "hooks", "prepare-commit-msg"); !strings.HasSuffix(got, want) {
+			t.Fatalf("HookPath() = %q, want suffix %q", got, want)
+		}
+
+		runGitCmd(t, repoDir, "config", "core.hooksPath", ".githooks")
+
+		got, err = HookPath("prepare-commit-msg")
+		if err != nil {
+			t.Fatalf("HookPath() error: %v", err)
+		}
+		if want := filepath.Join(".githooks", "prepare-commit-msg"); !strings.HasSuffix(got, want) || !filepath.IsAbs(got) {
+			t.Fatalf("HookPath() = %q, want absolute path ending in %q", got, want)
 		}
 	})
 }
@@ -148,3 +319,45 @@ func TestRunGitErrorIncludesStderr(t *testing.T) {
 		t.Fatalf("runGit() error %q missing command name", msg)
 	}
 }
+
+func TestCommitRangeHelpers(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+
+	withWorkdir(t, repoDir, func() {
+		first, err := HeadCommit()
+		if err != nil {
+			t.Fatalf("HeadCommit() error: %v", err)
+		}
+
+		writeFile(t, filepath.Join(repoDir, "readme.txt"), "beta\n")
+		runGitCmd(t, repoDir, "commit", "-am", "replace content")
+
+		head, err := HeadCommit()
+		if err != nil || head == first || len(head) != 40 {
+			t.Fatalf("HeadCommit() = %q, %v; want a new full SHA", head, err)
+		}
+		if !IsAncestor(first) || !IsAncestor(head) {
+			t.Fatal("IsAncestor() = false for a commit on the current branch")
+		}
+		if IsAncestor("0000000000000000000000000000000000000000") {
+			t.Fatal("IsAncestor() = true for an unknown commit")
+		}
+
+		patch, err := LoadRangePatch(first, head)
+		if err != nil {
+			t.Fatalf("LoadRangePatch() error: %v", err)
+		}
+		if len(patch.Files) != 1 || patch.Files[0].Added != 1 || patch.Files[0].Removed != 1 {
+			t.Fatalf("LoadRangePatch() files = %+v", patch.Files)
+		}
+
+		path, err := GitPath("pcl-comments.json")
+		if err != nil {
+			t.Fatalf("GitPath() error: %v", err)
+		}
+		if want := filepath.Join(".git", "pcl-comments.json"); !strings.HasSuffix(path, want) || !filepath.IsAbs(path) {
+			t.Fatalf("GitPath() = %q, want absolute path ending in %q", path, want)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package gittool
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// issueKeyPattern은 PCL-123 형태의 Jira 이슈 키다. 프로젝트 키는 대문자로 시작한다.
+var issueKeyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+)-[1-9][0-9]*\b`)
+
+// lettersOnly는 프로젝트 키가 영문 대문자로만 이뤄졌는지 확인한다.
+var lettersOnly = regexp.MustCompile(`^[A-Z]+$`)
+
+// notProjectKeys는 이슈 키처럼 보이지만 표준·알고리즘 이름인 접두사다 (UTF-8, SHA-256, ISO-8601 등).
+var notProjectKeys = []string{"AES", "CVE", "HTTP", "ISO", "RFC", "SHA", "SSL", "TLS", "UTF"}
+
+// ExtractIssueKeys는 text에 나오는 Jira 이슈 키를 처음 나온 순서대로 중복 없이 반환한다.
+// project가 있으면 그 프로젝트의 키만 찾는다. 없으면 프로젝트 키가 영문 대문자로만 된 키만 찾고,
+// UTF-8, SHA-256처럼 표준·알고리즘 이름인 것은 건너뛴다.
+func ExtractIssueKeys(text, project string) []string {
+	project = strings.ToUpper(strings.TrimSpace(project))
+	var keys []string
+	for _, m := range issueKeyPattern.FindAllStringSubmatch(text, -1) {
+		key, prefix := m[0], m[1]
+		if project != "" && prefix != project {
+			continue
+		}
+		if project == "" && (!lettersOnly.MatchString(prefix) || slices.Contains(notProjectKeys, prefix)) {
+			continue
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// CurrentBranch는 현재 브랜치 이름을 반환한다. detached HEAD이면 빈 문자열이다.
+func CurrentBranch() (string, error) {
+	branch, err := runGit("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("gittool: current branch: %w", err)
+	}
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// RecentCommitMessages는 base 브랜치의 fork point 이후 HEAD까지의 커밋 메시지를 최신순으로 최대 limit개 반환한다.
+// base가 비어 있으면 HEAD부터 limit개를 반환한다.
+func RecentCommitMessages(base string, limit int) ([]string, error) {
+	from := ""
+	if base != "" {
+		from = detectUpstream(base)
+	}
+	return CommitMessagesSince(from, limit)
+}
+
+// CommitMessagesSince는 from 커밋 이후 HEAD까지의 커밋 메시지를 최신순으로 최대 limit개 반환한다.
+// from이 비어 있으면 HEAD부터 limit개를 반환한다.
+func CommitMessagesSince(from string, limit int) ([]string, error) {
+	args := []string{"log", "-z", "--format=%B", "-n", strconv.Itoa(max(limit, 1))}
+	if from != "" {
+		args = append(args, from+"..HEAD")
+	}
+	out, err := runGit(args...)
+	if err != nil {
+		return nil, fmt.Errorf("gittool: recent commit messages: %w", err)
+	}
+
+	var messages []string
+	for _, m := range strings.Split(out, "\x00") {
+		if m = strings.TrimSpace(m); m != "" {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// recentCommitLimit는 이슈 키를 찾을 때 살펴보는 최근 커밋 수다.
+const recentCommitLimit = 20
+
+// DetectIssueKeys는 현재 브랜치 이름과, base가 있으면 base 이후의 커밋 메시지에서 project의 이슈 키를 찾는다.
+// 브랜치 이름의 키가 먼저 온다. base가 비어 있으면 다른 작업의 커밋을 섞지 않도록 브랜치 이름만 본다.
+// project가 비어 있으면 커밋 본문의 HTTP-2 같은 낱말을 키로 오인하지 않도록 브랜치 이름만 본다.
+func DetectIssueKeys(base, project string) ([]string, error) {
+	branch, err := CurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	keys := ExtractIssueKeys(branch, project)
+	if base == "" || strings.TrimSpace(project) == "" {
+		return keys, nil
+	}
+
+	messages, err := RecentCommitMessages(base, recentCommitLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range ExtractIssueKeys(strings.Join(messages, "\n"), project) {
+		if !slices.Contains(keys, k) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
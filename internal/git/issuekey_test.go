@@ -0,0 +1,88 @@
+package gittool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractIssueKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		project string
+		want    []string
+	}{
+		{"branch", "feature/PCL-123-retry-logic", "", []string{"PCL-123"}},
+		{"commitMessage", "fix: 재시도 간격 조정 (PCL-7, OPS-40)\n\nRefs: PCL-7", "", []string{"PCL-7", "OPS-40"}},
+		{"project", "fix: 재시도 간격 조정 (PCL-7, OPS_2-40)", "ops_2", []string{"OPS_2-40"}},
+		{"projectNoMatch", "fix: PCL-7 정리", "OPS", nil},
+		{"digitsInProjectNeedConfig", "fix: OPS_2-40, H2-3", "", nil},
+		{"utf8", "fix: UTF-8 디코딩 수정", "", nil},
+		{"sha256", "feat: SHA-256 체크섬과 ISO-8601 시간, HTTP-2 지원", "", nil},
+		{"utf8WithProject", "fix: UTF-8 디코딩 수정 (PCL-3)", "PCL", []string{"PCL-3"}},
+		{"lowercase", "feature/pcl-123-retry", "", nil},
+		{"zeroPadded", "PCL-0123", "", nil},
+		{"wordBoundary", "xPCL-1 PCL-2_x PCL-3", "", []string{"PCL-3"}},
+		{"none", "main", "", nil},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			if got := ExtractIssueKeys(caseData.input, caseData.project); !reflect.DeepEqual(got, caseData.want) {
+				t.Fatalf("ExtractIssueKeys(%q, %q) = %v, want %v", caseData.input, caseData.project, got, caseData.want)
+			}
+		})
+	}
+}
+
+func TestDetectIssueKeys(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+
+	runGitCmd(t, repoDir, "commit", "--allow-empty", "-m", "chore: PCL-1 정리")
+	runGitCmd(t, repoDir, "checkout", "-b", "feature/PCL-123-retry-logic")
+	runGitCmd(t, repoDir, "commit", "--allow-empty", "-m", "feat: 재시도 추가\n\nRefs: PCL-9, HTTP-2, OPS-4")
+
+	withWorkdir(t, repoDir, func() {
+		branch, err := CurrentBranch()
+		if err != nil || branch != "feature/PCL-123-retry-logic" {
+			t.Fatalf("CurrentBranch() = %q, %v", branch, err)
+		}
+
+		keys, err := DetectIssueKeys("main", "PCL")
+		if err != nil {
+			t.Fatalf("DetectIssueKeys() error: %v", err)
+		}
+		if want := []string{"PCL-123", "PCL-9"}; !reflect.DeepEqual(keys, want) {
+			t.Fatalf("DetectIssueKeys(main, PCL) = %v, want %v (commits on main and other projects must be ignored)", keys, want)
+		}
+
+		keys, err = DetectIssueKeys("", "PCL")
+		if err != nil || !reflect.DeepEqual(keys, []string{"PCL-123"}) {
+			t.Fatalf("DetectIssueKeys(\"\", PCL) = %v, %v; want branch key only", keys, err)
+		}
+
+		keys, err = DetectIssueKeys("main", "")
+		if err != nil || !reflect.DeepEqual(keys, []string{"PCL-123"}) {
+			t.Fatalf("DetectIssueKeys(main, \"\") = %v, %v; want branch key only without a project", keys, err)
+		}
+
+		keys, err = DetectIssueKeys("main", "OPS")
+		if err != nil || !reflect.DeepEqual(keys, []string{"OPS-4"}) {
+			t.Fatalf("DetectIssueKeys(main, OPS) = %v, %v; want OPS-4 only", keys, err)
+		}
+
+		messages, err := RecentCommitMessages("", 2)
+		if err != nil || len(messages) != 2 || messages[0] != "feat: 재시도 추가\n\nRefs: PCL-9, HTTP-2, OPS-4" {
+			t.Fatalf("RecentCommitMessages() = %q, %v", messages, err)
+		}
+	})
+
+	runGitCmd(t, repoDir, "checkout", "--detach")
+	withWorkdir(t, repoDir, func() {
+		if branch, err := CurrentBranch(); err != nil || branch != "" {
+			t.Fatalf("CurrentBranch() detached = %q, %v; want empty", branch, err)
+		}
+	})
+}
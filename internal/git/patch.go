@@ -0,0 +1,302 @@
+package gittool
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FileStatus는 diff에서 파일이 어떻게 바뀌었는지를 나타낸다.
+type FileStatus string
+
+const (
+	StatusModified FileStatus = "modified"
+	StatusAdded    FileStatus = "added"
+	StatusDeleted  FileStatus = "deleted"
+	StatusRenamed  FileStatus = "renamed"
+	StatusCopied   FileStatus = "copied"
+)
+
+// Hunk는 "@@ -a,b +c,d @@" 로 시작하는 변경 블록 하나다.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	// Header는 "@@ ... @@" 줄 전체다(함수 문맥 포함).
+	Header string
+	// Lines는 "+", "-", " ", "\" 접두사를 유지한 본문 줄이다.
+	Lines []string
+}
+
+// FileDiff는 파일 하나의 변경 내역이다.
+type FileDiff struct {
+	OldPath string
+	NewPath string
+	Status  FileStatus
+	Binary  bool
+	// Header는 "diff --git"부터 첫 헌크 전까지의 원본 줄이다.
+	Header  []string
+	Hunks   []Hunk
+	Added   int
+	Removed int
+}
+
+// Path는 파일을 대표하는 경로다. 삭제된 파일은 이전 경로를 사용한다.
+func (f FileDiff) Path() string {
+	if f.Status == StatusDeleted {
+		return f.OldPath
+	}
+	return f.NewPath
+}
+
+// String은 git diff 형식으로 파일 diff를 다시 출력한다.
+func (f FileDiff) String() string {
+	var b strings.Builder
+	for _, line := range f.Header {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	for _, h := range f.Hunks {
+		b.WriteString(h.String())
+	}
+	return b.String()
+}
+
+func (h Hunk) String() string {
+	var b strings.Builder
+	b.WriteString(h.Header)
+	b.WriteByte('\n')
+	for _, line := range h.Lines {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Patch는 파싱된 git diff 전체다.
+type Patch struct {
+	Files []FileDiff
+}
+
+// Empty는 변경된 파일이 없는지 알려준다.
+func (p *Patch) Empty() bool {
+	return p == nil || len(p.Files) == 0
+}
+
+// String은 git diff 형식으로 전체 diff를 다시 출력한다.
+func (p *Patch) String() string {
+	if p == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, f := range p.Files {
+		b.WriteString(f.String())
+	}
+	return b.String()
+}
+
+// Stat은 "상태 경로 (+추가 -삭제)" 형식의 파일별 요약이다.
+func (p *Patch) Stat() string {
+	if p == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, f := range p.Files {
+		path := f.Path()
+		if f.Status == StatusRenamed || f.Status == StatusCopied {
+			path = f.OldPath + " -> " + f.NewPath
+		}
+		if f.Binary {
+			fmt.Fprintf(&b, "%s %s (binary)\n", f.Status, path)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s (+%d -%d)\n", f.Status, path, f.Added, f.Removed)
+	}
+	return b.String()
+}
+
+// ParsePatch는 `git diff` 출력을 파일/헌크 구조로 파싱한다.
+func ParsePatch(raw string) (*Patch, error) {
+	patch := &Patch{}
+	if strings.TrimSpace(raw) == "" {
+		return patch, nil
+	}
+
+	var (
+		file *FileDiff
+		hunk *Hunk
+	)
+	flush := func() {
+		if file == nil {
+			return
+		}
+		if hunk != nil {
+			file.Hunks = append(file.Hunks, *hunk)
+			hunk = nil
+		}
+		patch.Files = append(patch.Files, *file)
+		file = nil
+	}
+
+	lines := strings.Split(strings.TrimRight(raw, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			flush()
+			file = &FileDiff{Status: StatusModified, Header: []string{line}}
+			file.OldPath, file.NewPath = parseGitHeaderPaths(strings.TrimPrefix(line, "diff --git "))
+
+		case file == nil:
+			return nil, fmt.Errorf("gittool: parse diff line %d: content before file header: %q", i+1, line)
+
+		case strings.HasPrefix(line, "@@"):
+			if hunk != nil {
+				file.Hunks = append(file.Hunks, *hunk)
+			}
+			h, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("gittool: parse diff line %d: %w", i+1, err)
+			}
+			hunk = &h
+
+		case hunk != nil:
+			hunk.Lines = append(hunk.Lines, line)
+			switch {
+			case strings.HasPrefix(line, "+"):
+				file.Added++
+			case strings.HasPrefix(line, "-"):
+				file.Removed++
+			}
+
+		default:
+			file.Header = append(file.Header, line)
+			parseExtendedHeader(file, line)
+		}
+	}
+	flush()
+
+	return patch, nil
+}
+
+// parseExtendedHeader는 헌크 앞의 확장 헤더 줄에서 상태와 경로를 읽는다.
+func parseExtendedHeader(f *FileDiff, line string) {
+	switch {
+	case strings.HasPrefix(line, "new file mode"):
+		f.Status = StatusAdded
+	case strings.HasPrefix(line, "deleted file mode"):
+		f.Status = StatusDeleted
+	case strings.HasPrefix(line, "rename from "):
+		f.Status = StatusRenamed
+		f.OldPath = unquotePath(strings.TrimPrefix(line, "rename from "))
+	case strings.HasPrefix(line, "rename to "):
+		f.Status = StatusRenamed
+		f.NewPath = unquotePath(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "copy from "):
+		f.Status = StatusCopied
+		f.OldPath = unquotePath(strings.TrimPrefix(line, "copy from "))
+	case strings.HasPrefix(line, "copy to "):
+		f.Status = StatusCopied
+		f.NewPath = unquotePath(strings.TrimPrefix(line, "copy to "))
+	case strings.HasPrefix(line, "--- "):
+		if p := stripPrefix(unquotePath(strings.TrimPrefix(line, "--- ")), "a/"); p != "/dev/null" {
+			f.OldPath = p
+		}
+	case strings.HasPrefix(line, "+++ "):
+		if p := stripPrefix(unquotePath(strings.TrimPrefix(line, "+++ ")), "b/"); p != "/dev/null" {
+			f.NewPath = p
+		}
+	case strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch":
+		f.Binary = true
+	}
+}
+
+// parseGitHeaderPaths는 "a/OLD b/NEW" 에서 경로를 읽는다.
+// 경로에 공백이 있을 수 있으므로 두 경로가 같다고 가정하고 가운데를 기준으로 나눈 뒤,
+// 그렇지 않으면 " b/" 를 기준으로 나눈다. 정확한 경로는 ---/+++ 또는 rename 줄이 덮어쓴다.
+func parseGitHeaderPaths(s string) (string, string) {
+	if strings.HasPrefix(s, `"`) {
+		if end := closingQuote(s); end > 0 {
+			oldPath := unquotePath(s[:end+1])
+			newPath := unquotePath(strings.TrimSpace(s[end+1:]))
+			return stripPrefix(oldPath, "a/"), stripPrefix(newPath, "b/")
+		}
+	}
+
+	if n := len(s); (n-1)%2 == 0 {
+		half := (n - 1) / 2
+		if s[half] == ' ' && strings.HasPrefix(s, "a/") && strings.HasPrefix(s[half+1:], "b/") && s[2:half] == s[half+3:] {
+			return s[2:half], s[half+3:]
+		}
+	}
+
+	if i := strings.Index(s, " b/"); i >= 0 {
+		return stripPrefix(s[:i], "a/"), unquotePath(s[i+3:])
+	}
+	return s, s
+}
+
+func parseHunkHeader(line string) (Hunk, error) {
+	h := Hunk{Header: line}
+
+	rest := strings.TrimPrefix(line, "@@ ")
+	end := strings.Index(rest, " @@")
+	if end < 0 {
+		return h, fmt.Errorf("malformed hunk header %q", line)
+	}
+
+	ranges := strings.Fields(rest[:end])
+	if len(ranges) != 2 || !strings.HasPrefix(ranges[0], "-") || !strings.HasPrefix(ranges[1], "+") {
+		return h, fmt.Errorf("malformed hunk header %q", line)
+	}
+
+	var err error
+	if h.OldStart, h.OldLines, err = parseRange(ranges[0][1:]); err != nil {
+		return h, fmt.Errorf("malformed hunk header %q: %w", line, err)
+	}
+	if h.NewStart, h.NewLines, err = parseRange(ranges[1][1:]); err != nil {
+		return h, fmt.Errorf("malformed hunk header %q: %w", line, err)
+	}
+	return h, nil
+}
+
+// parseRange는 "start,count" 또는 "start"(count=1)를 읽는다.
+func parseRange(s string) (int, int, error) {
+	startStr, countStr, hasCount := strings.Cut(s, ",")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !hasCount {
+		return start, 1, nil
+	}
+	count, err := strconv.Atoi(countStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, count, nil
+}
+
+// unquotePath는 git이 C 스타일로 인용한 경로("a/\303\244.txt")를 원래 문자열로 되돌린다.
+func unquotePath(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		if u, err := strconv.Unquote(s); err == nil {
+			return u
+		}
+	}
+	return s
+}
+
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func stripPrefix(s, prefix string) string {
+	return strings.TrimPrefix(s, prefix)
+}
@@ -0,0 +1,122 @@
+package gittool
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePatch(t *testing.T) {
+	raw := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -3,0 +4,2 @@ func main() {
++	a := 1
++	b := 2
+@@ -10 +12 @@ func helper() {
+-	return 0
++	return 1
+diff --git a/old name.txt b/new name.txt
+similarity index 90%
+rename from old name.txt
+rename to new name.txt
+diff --git a/logo.png b/logo.png
+new file mode 100644
+index 0000000..3333333
+Binary files /dev/null and b/logo.png differ
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+index 4444444..0000000
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+`
+
+	patch := mustParse(t, raw)
+	if len(patch.Files) != 4 {
+		t.Fatalf("ParsePatch() returned %d files, want 4", len(patch.Files))
+	}
+
+	mod := patch.Files[0]
+	if mod.Status != StatusModified || mod.Path() != "main.go" {
+		t.Fatalf("file 0 = %s %s", mod.Status, mod.Path())
+	}
+	if len(mod.Hunks) != 2 || mod.Added != 3 || mod.Removed != 1 {
+		t.Fatalf("file 0 hunks=%d added=%d removed=%d", len(mod.Hunks), mod.Added, mod.Removed)
+	}
+	h := mod.Hunks[0]
+	if h.OldStart != 3 || h.OldLines != 0 || h.NewStart != 4 || h.NewLines != 2 {
+		t.Fatalf("hunk 0 range = %+v", h)
+	}
+	if h2 := mod.Hunks[1]; h2.OldStart != 10 || h2.OldLines != 1 || h2.NewStart != 12 || h2.NewLines != 1 {
+		t.Fatalf("hunk 1 range = %+v", h2)
+	}
+
+	ren := patch.Files[1]
+	if ren.Status != StatusRenamed || ren.OldPath != "old name.txt" || ren.NewPath != "new name.txt" {
+		t.Fatalf("rename = %s %q -> %q", ren.Status, ren.OldPath, ren.NewPath)
+	}
+
+	bin := patch.Files[2]
+	if !bin.Binary || bin.Status != StatusAdded || bin.Path() != "logo.png" {
+		t.Fatalf("binary = %+v", bin)
+	}
+
+	del := patch.Files[3]
+	if del.Status != StatusDeleted || del.Path() != "gone.txt" || del.Removed != 1 {
+		t.Fatalf("deleted = %+v", del)
+	}
+
+	if got := patch.String(); got != raw {
+		t.Fatalf("String() did not round-trip:\n%s", got)
+	}
+}
+
+func TestParsePatchQuotedPath(t *testing.T) {
+	raw := `diff --git "a/\303\244.txt" "b/\303\244.txt"
+index 1..2 100644
+--- "a/\303\244.txt"
++++ "b/\303\244.txt"
+@@ -1 +1 @@
+-a
++b
+`
+	patch := mustParse(t, raw)
+	if got := patch.Files[0].Path(); got != "ä.txt" {
+		t.Fatalf("Path() = %q, want ä.txt", got)
+	}
+}
+
+func TestParsePatchRejectsMalformedHunk(t *testing.T) {
+	if _, err := ParsePatch("diff --git a/x b/x\n@@ nonsense\n"); err == nil {
+		t.Fatal("ParsePatch() expected error for malformed hunk header")
+	}
+}
+
+func TestParsePatchEmpty(t *testing.T) {
+	if !mustParse(t, "").Empty() {
+		t.Fatal("ParsePatch(\"\") should be empty")
+	}
+}
+
+func TestLoadPatchFromRepository(t *testing.T) {
+	repoDir, cleanup := initGitRepo(t)
+	t.Cleanup(cleanup)
+
+	writeFile(t, filepath.Join(repoDir, "readme.txt"), "beta\n")
+	runGitCmd(t, repoDir, "add", "readme.txt")
+
+	withWorkdir(t, repoDir, func() {
+		patch, err := LoadPatch(SourceStaged, "")
+		if err != nil {
+			t.Fatalf("LoadPatch() error: %v", err)
+		}
+		if len(patch.Files) != 1 || patch.Files[0].Path() != "readme.txt" {
+			t.Fatalf("LoadPatch() files = %+v", patch.Files)
+		}
+		if patch.Files[0].Added != 1 || patch.Files[0].Removed != 1 {
+			t.Fatalf("LoadPatch() counts = +%d -%d", patch.Files[0].Added, patch.Files[0].Removed)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package gittool
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DiffText는 old와 new 텍스트의 unified diff를 반환한다. 같으면 빈 문자열이다.
+// 헤더는 "--- oldLabel", "+++ newLabel"로 바꿔 임시 파일 경로를 드러내지 않는다.
+func DiffText(oldLabel, newLabel, old, new string) (string, error) {
+	dir, err := os.MkdirTemp("", "pcl-diff-*")
+	if err != nil {
+		return "", fmt.Errorf("gittool: diff text: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldPath, newPath := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+	if err := os.WriteFile(oldPath, []byte(ensureNewline(old)), 0o600); err != nil {
+		return "", fmt.Errorf("gittool: diff text: %w", err)
+	}
+	if err := os.WriteFile(newPath, []byte(ensureNewline(new)), 0o600); err != nil {
+		return "", fmt.Errorf("gittool: diff text: %w", err)
+	}
+
+	// --no-index는 차이가 있으면 종료 코드 1을 반환하므로 runGit 대신 직접 실행한다.
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--no-ext-diff", oldPath, newPath)
+	var out, errb bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errb
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			return "", fmt.Errorf("gittool: diff text: %v: %s", err, strings.TrimSpace(errb.String()))
+		}
+	}
+
+	var b strings.Builder
+	inHeader := true
+	for _, line := range strings.SplitAfter(out.String(), "\n") {
+		if inHeader {
+			switch {
+			case strings.HasPrefix(line, "--- "):
+				b.WriteString("--- " + oldLabel + "\n")
+			case strings.HasPrefix(line, "+++ "):
+				b.WriteString("+++ " + newLabel + "\n")
+				inHeader = false
+			}
+			continue
+		}
+		b.WriteString(line)
+	}
+	return b.String(), nil
+}
+
+func ensureNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
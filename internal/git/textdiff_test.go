@@ -0,0 +1,29 @@
+package gittool
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffText(t *testing.T) {
+	old := "### 배경\n- 재시도 없음\n[ ] 타임아웃 조정"
+	new := "### 배경\n- 재시도 없음\n[x] 타임아웃 조정\n[ ] 문서화\n"
+
+	diff, err := DiffText("PCL-1 (현재)", "PCL-1 (갱신 후)", old, new)
+	if err != nil {
+		t.Fatalf("DiffText() error: %v", err)
+	}
+	for _, want := range []string{"--- PCL-1 (현재)\n", "+++ PCL-1 (갱신 후)\n", "-[ ] 타임아웃 조정\n", "+[x] 타임아웃 조정\n", "+[ ] 문서화\n"} {
+		if !strings.Contains(diff, want) {
+			t.Fatalf("DiffText() missing %q:\n%s", want, diff)
+		}
+	}
+	if strings.Contains(diff, "diff --git") || strings.Contains(diff, "pcl-diff-") {
+		t.Fatalf("DiffText() leaked temporary file headers:\n%s", diff)
+	}
+
+	same, err := DiffText("a", "b", "같음\n", "같음")
+	if err != nil || same != "" {
+		t.Fatalf("DiffText() identical = %q, %v; want empty", same, err)
+	}
+}
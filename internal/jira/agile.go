@@ -0,0 +1,59 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// agilePath는 Jira Software REST API 경로다. Cloud와 Data Center 모두 1.0을 쓴다.
+func agilePath(p string) string {
+	return "/rest/agile/1.0" + p
+}
+
+// Board는 Jira Software 보드다.
+type Board struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Sprint는 보드의 스프린트다.
+type Sprint struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// ActiveSprint는 보드의 진행 중인 스프린트를 반환한다. boardID가 0이면 project의 첫 스크럼 보드를 쓴다.
+// 진행 중인 스프린트가 여러 개면 Jira가 돌려준 목록의 첫 번째다.
+func (c *Client) ActiveSprint(ctx context.Context, project string, boardID int) (*Sprint, error) {
+	if boardID == 0 {
+		q := url.Values{"projectKeyOrId": {project}, "type": {"scrum"}}
+		boards, err := fetchPages[Board](ctx, c, "list boards", agilePath("/board")+"?"+q.Encode())
+		if err != nil {
+			return nil, err
+		}
+		if len(boards) == 0 {
+			return nil, fmt.Errorf("jira: find active sprint failed: project %s has no scrum board", project)
+		}
+		boardID = boards[0].ID
+	}
+
+	path := agilePath(fmt.Sprintf("/board/%d/sprint", boardID)) + "?state=active"
+	sprints, err := fetchPages[Sprint](ctx, c, "list sprints", path)
+	if err != nil {
+		return nil, err
+	}
+	if len(sprints) == 0 {
+		return nil, fmt.Errorf("jira: find active sprint failed: board %d has no active sprint", boardID)
+	}
+	return &sprints[0], nil
+}
+
+// AddToSprint는 keys 이슈를 sprintID 스프린트로 옮긴다.
+func (c *Client) AddToSprint(ctx context.Context, sprintID int, keys ...string) error {
+	body := map[string][]string{"issues": keys}
+	return c.do(ctx, "add to sprint", http.MethodPost, agilePath(fmt.Sprintf("/sprint/%d/issue", sprintID)), body, nil)
+}
@@ -0,0 +1,80 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestActiveSprint(t *testing.T) {
+	var added string
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board":
+			if q := r.URL.Query(); q.Get("projectKeyOrId") != "PCL" || q.Get("type") != "scrum" || q.Get("startAt") != "0" {
+				t.Fatalf("unexpected board query %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"startAt":0,"maxResults":50,"isLast":true,"values":[{"id":7,"name":"PCL board","type":"scrum"}]}`))
+		case "/rest/agile/1.0/board/7/sprint", "/rest/agile/1.0/board/9/sprint":
+			if r.URL.Query().Get("state") != "active" {
+				t.Fatalf("unexpected sprint query %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"startAt":0,"maxResults":50,"isLast":true,"values":[{"id":42,"name":"PCL Sprint 12","state":"active"}]}`))
+		case "/rest/agile/1.0/board/8/sprint":
+			_, _ = w.Write([]byte(`{"startAt":0,"maxResults":50,"isLast":true,"values":[]}`))
+		case "/rest/agile/1.0/sprint/42/issue":
+			data, _ := io.ReadAll(r.Body)
+			added = string(data)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts.URL)
+	sprint, err := client.ActiveSprint(context.Background(), "PCL", 0)
+	if err != nil {
+		t.Fatalf("ActiveSprint() error: %v", err)
+	}
+	if sprint.ID != 42 || sprint.Name != "PCL Sprint 12" {
+		t.Fatalf("ActiveSprint() = %+v", sprint)
+	}
+	if _, err := client.ActiveSprint(context.Background(), "PCL", 9); err != nil {
+		t.Fatalf("ActiveSprint() with board error: %v", err)
+	}
+	if _, err := client.ActiveSprint(context.Background(), "PCL", 8); err == nil {
+		t.Fatal("ActiveSprint() expected error when the board has no active sprint")
+	}
+
+	if err := client.AddToSprint(context.Background(), sprint.ID, "PCL-7"); err != nil {
+		t.Fatalf("AddToSprint() error: %v", err)
+	}
+	if added != `{"issues":["PCL-7"]}` {
+		t.Fatalf("body = %s", added)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/label" || r.URL.Query().Get("maxResults") != "1000" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"maxResults":1000,"startAt":0,"total":2,"isLast":true,"values":["backend","security"]}`))
+	}))
+	defer ts.Close()
+
+	labels, err := newTestClient(ts.URL).Labels(context.Background())
+	if err != nil {
+		t.Fatalf("Labels() error: %v", err)
+	}
+	if len(labels) != 2 || labels[1] != "security" {
+		t.Fatalf("Labels() = %v", labels)
+	}
+
+	dc := NewClient(Options{Host: "http://127.0.0.1:1", Deployment: DeploymentDataCenter, Token: "token123"})
+	if labels, err := dc.Labels(context.Background()); err != nil || labels != nil {
+		t.Fatalf("Labels() on Data Center = %v, %v; want nil, nil", labels, err)
+	}
+}
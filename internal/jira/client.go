@@ -0,0 +1,263 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// DefaultTimeout은 요청 하나의 기본 제한 시간이다.
+	DefaultTimeout = 8 * time.Second
+	// DefaultMaxRetries는 429/5xx 응답을 다시 시도하는 기본 횟수다.
+	DefaultMaxRetries = 3
+
+	defaultRetryWait = 500 * time.Millisecond
+	maxRetryWait     = 30 * time.Second
+	maxRetryAfter    = time.Minute
+)
+
+// Deployment는 Jira 배포 형태다.
+type Deployment string
+
+const (
+	// DeploymentCloud는 Jira Cloud다. 이메일+API 토큰 기본 인증, REST API v3, ADF 설명을 사용한다.
+	DeploymentCloud Deployment = "cloud"
+	// DeploymentDataCenter는 Jira Data Center/Server다. PAT Bearer 인증, REST API v2, wiki markup 설명을 사용한다.
+	DeploymentDataCenter Deployment = "datacenter"
+)
+
+// Options는 Client 설정이다.
+type Options struct {
+	Host string
+	// Deployment가 비어 있으면 DeploymentCloud다.
+	Deployment Deployment
+	// Email은 Cloud 기본 인증에만 사용한다.
+	Email string
+	// Token은 Cloud에서는 API 토큰, Data Center에서는 Personal Access Token이다.
+	Token string
+	// Timeout이 0이면 DefaultTimeout을 사용한다.
+	Timeout time.Duration
+	// MaxRetries는 429/5xx 응답을 다시 시도하는 횟수다. POST는 429/503만 다시 시도한다. 0이면 다시 시도하지 않는다.
+	MaxRetries int
+	// RetryWait는 지수 백오프의 첫 대기 시간이다. 0이면 500ms다.
+	RetryWait time.Duration
+	// MetaCacheDir가 비어 있으면 프로젝트 메타데이터를 캐시하지 않는다.
+	MetaCacheDir string
+	// MetaCacheTTL이 0이면 DefaultMetaCacheTTL을 사용한다.
+	MetaCacheTTL time.Duration
+}
+
+// Client는 Jira REST API 클라이언트다. 한 번 만들어 여러 요청에 재사용한다.
+type Client struct {
+	http       *resty.Client
+	host       string
+	deployment Deployment
+	maxRetries int
+	retryWait  time.Duration
+	// sleep은 재시도 전 대기다. 테스트에서 바꿔 끼운다.
+	sleep func(ctx context.Context, d time.Duration) error
+
+	metaCacheDir string
+	metaCacheTTL time.Duration
+	// metas는 ProjectMeta로 읽은 프로젝트별 메타데이터다. CreateIssue 검증에 쓴다.
+	metas map[string]*ProjectMeta
+}
+
+// NewClient는 opts로 Client를 만든다.
+func NewClient(opts Options) *Client {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	wait := opts.RetryWait
+	if wait <= 0 {
+		wait = defaultRetryWait
+	}
+	ttl := opts.MetaCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultMetaCacheTTL
+	}
+
+	deployment := opts.Deployment
+	if deployment == "" {
+		deployment = DeploymentCloud
+	}
+	auth := basicAuth(opts.Email, opts.Token)
+	if deployment == DeploymentDataCenter {
+		auth = "Bearer " + opts.Token
+	}
+
+	return &Client{
+		http: resty.New().
+			SetBaseURL(opts.Host).
+			SetTimeout(timeout).
+			SetHeader("Accept", "application/json").
+			SetHeader("Content-type", "application/json").
+			SetHeader("Authorization", auth),
+		host:         opts.Host,
+		deployment:   deployment,
+		maxRetries:   max(opts.MaxRetries, 0),
+		retryWait:    wait,
+		sleep:        sleepContext,
+		metaCacheDir: opts.MetaCacheDir,
+		metaCacheTTL: ttl,
+		metas:        map[string]*ProjectMeta{},
+	}
+}
+
+// Host는 Jira 사이트 주소다.
+func (c *Client) Host() string {
+	return c.host
+}
+
+// Deployment는 클라이언트가 접속하는 Jira 배포 형태다.
+func (c *Client) Deployment() Deployment {
+	return c.deployment
+}
+
+// apiPath는 배포 형태에 맞는 REST API 버전 경로를 붙인다 (Cloud는 v3, Data Center는 v2).
+func (c *Client) apiPath(p string) string {
+	if c.deployment == DeploymentDataCenter {
+		return "/rest/api/2" + p
+	}
+	return "/rest/api/3" + p
+}
+
+// APIError는 Jira가 실패 상태 코드로 응답했음을 뜻한다.
+// Jira의 오류 본문 형식({"errorMessages":[...],"errors":{...}})이면 각 필드를 채운다.
+type APIError struct {
+	StatusCode    int
+	ErrorMessages []string
+	Errors        map[string]string
+	// Body는 오류 본문 원문이다.
+	Body string
+
+	op string
+}
+
+func (e *APIError) Error() string {
+	detail := strings.TrimSpace(e.Body)
+	if len(e.ErrorMessages) > 0 || len(e.Errors) > 0 {
+		parts := append([]string{}, e.ErrorMessages...)
+		for _, field := range slices.Sorted(maps.Keys(e.Errors)) {
+			parts = append(parts, field+": "+e.Errors[field])
+		}
+		detail = strings.Join(parts, "; ")
+	}
+	if detail == "" {
+		detail = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("jira: %s failed: status %d: %s", e.op, e.StatusCode, detail)
+}
+
+// IsNotFound는 err가 404 응답인지 확인한다.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func newAPIError(op string, resp *resty.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode(), Body: string(resp.Body()), op: op}
+
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if json.Unmarshal(resp.Body(), &body) == nil {
+		apiErr.ErrorMessages = body.ErrorMessages
+		apiErr.Errors = body.Errors
+	}
+	return apiErr
+}
+
+// do는 요청을 보내고, retryable한 응답이면 maxRetries번까지 다시 시도한다.
+// 실패 상태 코드로 끝나면 *APIError를 반환한다.
+func (c *Client) do(ctx context.Context, op, method, path string, body, result any) error {
+	for attempt := 0; ; attempt++ {
+		req := c.http.R().SetContext(ctx)
+		if body != nil {
+			req.SetBody(body)
+		}
+
+		resp, err := req.Execute(method, path)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("jira: %s request failed: %w", op, ctx.Err())
+			}
+			return fmt.Errorf("jira: %s request failed: %w", op, err)
+		}
+		if !resp.IsError() {
+			if result == nil || len(resp.Body()) == 0 {
+				return nil
+			}
+			if err := json.Unmarshal(resp.Body(), result); err != nil {
+				return fmt.Errorf("jira: %s failed: decode response: %w", op, err)
+			}
+			return nil
+		}
+
+		if !retryable(method, resp.StatusCode()) || attempt >= c.maxRetries {
+			return newAPIError(op, resp)
+		}
+		if err := c.sleep(ctx, c.retryDelay(attempt, resp)); err != nil {
+			return fmt.Errorf("jira: %s request failed: %w", op, err)
+		}
+	}
+}
+
+// retryable은 다시 보내도 작업이 두 번 일어나지 않는 응답인지 확인한다. GET/PUT/DELETE는 5xx와 429를 다시 시도한다.
+// POST는 Jira가 이미 이슈·댓글을 만든 뒤 502/504가 올 수 있으므로, 작업 전에 거절한 429와 503만 다시 시도한다.
+func retryable(method string, status int) bool {
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return status >= 500
+	}
+	return false
+}
+
+// retryDelay는 Retry-After 헤더가 있으면 그 값을, 없으면 지수 백오프 시간을 반환한다.
+func (c *Client) retryDelay(attempt int, resp *resty.Response) time.Duration {
+	if d, ok := parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()); ok {
+		return min(d, maxRetryAfter)
+	}
+	return min(c.retryWait<<min(attempt, 16), maxRetryWait)
+}
+
+// parseRetryAfter는 초 단위 숫자나 HTTP 날짜 형식의 Retry-After 값을 읽는다.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(secs, 0)) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,207 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordSleeps는 실제로 기다리지 않고 재시도 대기 시간만 기록한다.
+func recordSleeps(c *Client) *[]time.Duration {
+	var waits []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return &waits
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"accountId":"abc"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(Options{Host: ts.URL, MaxRetries: 3, RetryWait: 100 * time.Millisecond})
+	waits := recordSleeps(c)
+
+	got, err := c.CurrentUser(context.Background())
+	if err != nil || got.AccountID != "abc" {
+		t.Fatalf("CurrentUser() = %+v, %v; want abc", got, err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("requests = %d, want 3", calls.Load())
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}
+	if len(*waits) != 2 || (*waits)[0] != want[0] || (*waits)[1] != want[1] {
+		t.Fatalf("waits = %v, want %v", *waits, want)
+	}
+}
+
+func TestClientHonoursRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"accountId":"abc"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(Options{Host: ts.URL, MaxRetries: 1})
+	waits := recordSleeps(c)
+
+	if _, err := c.CurrentUser(context.Background()); err != nil {
+		t.Fatalf("CurrentUser() error: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 7*time.Second {
+		t.Fatalf("waits = %v, want [7s]", *waits)
+	}
+}
+
+func TestClientDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorMessages":["Project is archived"],"errors":{"summary":"Field too long","issuetype":"invalid"}}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(Options{Host: ts.URL, MaxRetries: 3})
+	recordSleeps(c)
+
+	_, err := c.CreateIssue(context.Background(), validPayload())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("CreateIssue() error = %v, want *APIError", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("requests = %d, want 1", calls.Load())
+	}
+	if apiErr.StatusCode != 400 || apiErr.ErrorMessages[0] != "Project is archived" || apiErr.Errors["summary"] != "Field too long" {
+		t.Fatalf("APIError = %+v", apiErr)
+	}
+	want := "jira: create issue failed: status 400: Project is archived; issuetype: invalid; summary: Field too long"
+	if apiErr.Error() != want {
+		t.Fatalf("Error() = %q, want %q", apiErr.Error(), want)
+	}
+}
+
+func TestClientDoesNotRetryPostServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c := NewClient(Options{Host: ts.URL, MaxRetries: 3})
+	recordSleeps(c)
+
+	_, err := c.CreateIssue(context.Background(), validPayload())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("CreateIssue() error = %v, want 502 APIError", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("requests = %d, want 1", calls.Load())
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		method string
+		status int
+		want   bool
+	}{
+		{http.MethodGet, http.StatusBadGateway, true},
+		{http.MethodPut, http.StatusInternalServerError, true},
+		{http.MethodDelete, http.StatusGatewayTimeout, true},
+		{http.MethodPost, http.StatusTooManyRequests, true},
+		{http.MethodPost, http.StatusServiceUnavailable, true},
+		{http.MethodPost, http.StatusBadGateway, false},
+		{http.MethodPost, http.StatusGatewayTimeout, false},
+		{http.MethodGet, http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		if got := retryable(caseData.method, caseData.status); got != caseData.want {
+			t.Fatalf("retryable(%s, %d) = %v, want %v", caseData.method, caseData.status, got, caseData.want)
+		}
+	}
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	c := NewClient(Options{Host: ts.URL, MaxRetries: 2})
+	recordSleeps(c)
+
+	_, err := c.CurrentUser(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("CurrentUser() error = %v, want 503 APIError", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("requests = %d, want 3", calls.Load())
+	}
+}
+
+func TestClientStopsRetryingWhenContextCanceled(t *testing.T) {
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := NewClient(Options{Host: ts.URL, MaxRetries: 5})
+	c.sleep = func(context.Context, time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	_, err := c.CurrentUser(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("CurrentUser() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, true},
+		{"Wed, 01 Jan 2025 00:00:10 GMT", 10 * time.Second, true},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
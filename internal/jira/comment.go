@@ -0,0 +1,39 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ledzpl/pcl/internal/adf"
+)
+
+// Comment는 댓글 생성 응답이다.
+type Comment struct {
+	ID   string `json:"id"`
+	Self string `json:"self"`
+}
+
+// AddComment는 key 이슈에 doc 내용의 댓글을 단다. Data Center에서는 wiki markup으로 바꿔 보낸다.
+func (c *Client) AddComment(ctx context.Context, key string, doc *adf.Node) (*Comment, error) {
+	if problems := adf.Validate(doc); len(problems) > 0 {
+		return nil, fmt.Errorf("jira: add comment failed: invalid body: %v", problems[0])
+	}
+
+	var body any = doc
+	if c.deployment == DeploymentDataCenter {
+		body = adf.ToWiki(doc)
+	}
+
+	var comment Comment
+	path := c.apiPath("/issue/" + url.PathEscape(key) + "/comment")
+	if err := c.do(ctx, "add comment", http.MethodPost, path, map[string]any{"body": body}, &comment); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(comment.ID) == "" {
+		return nil, fmt.Errorf("jira: add comment failed: missing id field")
+	}
+	return &comment, nil
+}
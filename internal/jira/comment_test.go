@@ -0,0 +1,57 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ledzpl/pcl/internal/adf"
+)
+
+func TestAddComment(t *testing.T) {
+	doc := adf.Doc(adf.Paragraph("재시도 추가"))
+
+	tests := []struct {
+		name       string
+		deployment Deployment
+		path       string
+		want       string
+	}{
+		{"cloud", DeploymentCloud, "/rest/api/3/issue/PCL-7/comment", `{"body":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"재시도 추가"}]}]}}`},
+		{"dataCenter", DeploymentDataCenter, "/rest/api/2/issue/PCL-7/comment", `{"body":"재시도 추가"}`},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			var body string
+			ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != caseData.path {
+					t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":"10100","self":"https://example.atlassian.net/rest/api/3/issue/10001/comment/10100"}`))
+			}))
+			defer ts.Close()
+
+			client := NewClient(Options{Host: ts.URL, Deployment: caseData.deployment, Email: "user@example.com", Token: "token123"})
+			comment, err := client.AddComment(context.Background(), "PCL-7", doc)
+			if err != nil {
+				t.Fatalf("AddComment() error: %v", err)
+			}
+			if comment.ID != "10100" {
+				t.Fatalf("AddComment() = %+v", comment)
+			}
+			if body != caseData.want {
+				t.Fatalf("body = %s, want %s", body, caseData.want)
+			}
+		})
+	}
+
+	if _, err := newTestClient("http://127.0.0.1:1").AddComment(context.Background(), "PCL-7", adf.Doc(&adf.Node{Type: "table"})); err == nil {
+		t.Fatal("AddComment() expected local validation error")
+	}
+}
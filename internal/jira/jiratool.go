@@ -1,70 +1,183 @@
 package jira
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
-	"time"
 
-	"github.com/go-resty/resty/v2"
+	"github.com/ledzpl/pcl/internal/adf"
 )
 
-func CreateIssue(request string, email string, host string, token string) error {
-	c := resty.New().
-		SetBaseURL(host).
-		SetTimeout(8*time.Second).
-		SetHeader("Accept", "application/json").
-		SetHeader("Content-type", "application/json").
-		SetHeader("Authorization", basicAuth(email, token))
+// CreatedIssue는 이슈 생성 응답이다.
+type CreatedIssue struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
 
-	resp, err := c.R().
-		SetBody(request).
-		Post("/rest/api/3/issue")
+// BrowseURL은 host에서 이슈 key를 여는 웹 주소다.
+func BrowseURL(host, key string) string {
+	return strings.TrimRight(host, "/") + "/browse/" + key
+}
 
-	if err != nil {
-		return fmt.Errorf("jira: create issue request failed: %w", err)
+// CreateIssue는 payload를 검증한 뒤 이슈를 생성하고, 생성된 이슈의 id/key/self를 반환한다.
+// 프로젝트 메타데이터를 읽어 두었다면 그 기준으로 검증한다. 검증에 실패하면 요청을 보내지 않는다.
+// Data Center에서는 설명을 wiki markup으로 바꾸고, 하위 작업이 아닌 이슈의 parent는 Epic Link 필드로 보낸다.
+func (c *Client) CreateIssue(ctx context.Context, payload *IssuePayload) (*CreatedIssue, error) {
+	meta := c.metas[payload.Fields.Project.Key]
+	if err := payload.ValidateFor(meta); err != nil {
+		return nil, err
 	}
 
-	if resp.IsError() {
-		body := strings.TrimSpace(string(resp.Body()))
-		if body == "" {
-			body = resp.Status()
+	var body any = payload
+	if c.deployment == DeploymentDataCenter {
+		wiki, err := withEpicLink(payload, meta).wikiBody()
+		if err != nil {
+			return nil, fmt.Errorf("jira: create issue failed: %w", err)
 		}
-		return fmt.Errorf("jira: create issue failed: status %d: %s", resp.StatusCode(), body)
+		body = wiki
+	}
+
+	var created CreatedIssue
+	if err := c.do(ctx, "create issue", http.MethodPost, c.apiPath("/issue"), body, &created); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(created.Key) == "" {
+		return nil, fmt.Errorf("jira: create issue failed: missing key field")
 	}
 
-	return nil
+	return &created, nil
 }
 
-func GetAccountId(email, host, token string) (string, error) {
-	c := resty.New().
-		SetBaseURL(host).
-		SetHeader("Accept", "application/json").
-		SetHeader("Authorization", basicAuth(email, token))
+// Issue는 조회한 기존 이슈다.
+type Issue struct {
+	ID     string       `json:"id"`
+	Key    string       `json:"key"`
+	Self   string       `json:"self"`
+	Fields IssueSummary `json:"fields"`
+}
 
-	var getOut map[string]any
-	resp, err := c.R().
-		SetResult(&getOut).
-		Get("/rest/api/3/myself")
+// IssueSummary는 조회한 이슈의 주요 필드다.
+type IssueSummary struct {
+	Summary   string       `json:"summary"`
+	IssueType IssueTypeRef `json:"issuetype"`
+	Status    struct {
+		Name string `json:"name"`
+	} `json:"status"`
+	// Description은 설명 원문이다. Cloud는 ADF JSON, Data Center는 wiki markup 문자열이고, 없으면 null이다.
+	Description json.RawMessage `json:"description"`
+}
 
-	if err != nil {
-		return "", fmt.Errorf("jira: get account id request failed: %w", err)
+// DescriptionSource는 설명을 모델에 보낼 원문으로 반환한다 (ADF JSON 또는 wiki markup).
+func (f IssueSummary) DescriptionSource() string {
+	var wiki string
+	if json.Unmarshal(f.Description, &wiki) == nil {
+		return wiki
 	}
+	if string(f.Description) == "null" {
+		return ""
+	}
+	return string(f.Description)
+}
 
-	if resp.IsError() {
-		body := strings.TrimSpace(string(resp.Body()))
-		if body == "" {
-			body = resp.Status()
-		}
-		return "", fmt.Errorf("jira: get account id failed: status %d: %s", resp.StatusCode(), body)
+// DescriptionText는 설명을 사람이 읽고 비교하기 좋은 텍스트로 반환한다.
+// ADF는 adf.Render로 바꾸고, wiki markup은 그대로 둔다.
+func (f IssueSummary) DescriptionText() string {
+	var doc adf.Node
+	if json.Unmarshal(f.Description, &doc) == nil && doc.Type == adf.TypeDoc {
+		return adf.Render(&doc)
 	}
+	return f.DescriptionSource()
+}
 
-	accountID, ok := getOut["accountId"].(string)
-	if !ok || strings.TrimSpace(accountID) == "" {
-		return "", fmt.Errorf("jira: get account id failed: missing accountId field")
+// UnsupportedDescriptionNodes는 ADF 설명에서 pcl이 다시 쓸 수 없는 노드(표, 멘션, 미디어 등) 이름을 반환한다.
+// wiki markup 설명이나 빈 설명이면 nil이다.
+func (f IssueSummary) UnsupportedDescriptionNodes() []string {
+	var doc adf.Node
+	if json.Unmarshal(f.Description, &doc) != nil || doc.Type != adf.TypeDoc {
+		return nil
 	}
+	return adf.Unsupported(&doc)
+}
 
-	return accountID, nil
+// GetIssue는 key 이슈의 제목, 타입, 상태, 설명을 조회한다. 이슈가 없으면 StatusCode가 404인 *APIError를 반환한다.
+func (c *Client) GetIssue(ctx context.Context, key string) (*Issue, error) {
+	var issue Issue
+	path := c.apiPath("/issue/"+url.PathEscape(key)) + "?fields=summary,issuetype,status,description"
+	if err := c.do(ctx, "get issue", http.MethodGet, path, nil, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// UpdateDescription은 key 이슈의 설명을 doc으로 바꾼다. Data Center에서는 wiki markup으로 바꿔 보낸다.
+func (c *Client) UpdateDescription(ctx context.Context, key string, doc *adf.Node) error {
+	if problems := adf.Validate(doc); len(problems) > 0 {
+		return fmt.Errorf("jira: update issue failed: invalid description: %v", problems[0])
+	}
+
+	var description any = doc
+	if c.deployment == DeploymentDataCenter {
+		description = adf.ToWiki(doc)
+	}
+	body := map[string]any{"fields": map[string]any{"description": description}}
+	return c.do(ctx, "update issue", http.MethodPut, c.apiPath("/issue/"+url.PathEscape(key)), body, nil)
+}
+
+// RenderDescription은 doc을 이 배포 형태에서 설명이 저장되는 모습에 가까운 텍스트로 바꾼다.
+// 조회한 설명의 DescriptionText와 비교하는 데 쓴다.
+func (c *Client) RenderDescription(doc *adf.Node) string {
+	if c.deployment == DeploymentDataCenter {
+		return adf.ToWiki(doc)
+	}
+	return adf.Render(doc)
+}
+
+// User는 Jira 사용자다. Cloud는 AccountID, Data Center는 Name(사용자 이름)으로 식별한다.
+type User struct {
+	AccountID   string `json:"accountId"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// Ref는 담당자 등 필드에 넣을 사용자 참조다.
+func (u User) Ref() *UserRef {
+	if u.AccountID != "" {
+		return &UserRef{AccountID: u.AccountID}
+	}
+	return &UserRef{Name: u.Name}
+}
+
+// ID는 사용자를 식별하는 값이다 (Cloud는 Account ID, Data Center는 사용자 이름).
+func (u User) ID() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Name
+}
+
+// CurrentUser는 인증한 사용자를 반환한다.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, "get current user", http.MethodGet, c.apiPath("/myself"), nil, &user); err != nil {
+		return nil, err
+	}
+
+	if c.deployment == DeploymentDataCenter {
+		if strings.TrimSpace(user.Name) == "" {
+			return nil, fmt.Errorf("jira: get current user failed: missing name field")
+		}
+		return &user, nil
+	}
+	if strings.TrimSpace(user.AccountID) == "" {
+		return nil, fmt.Errorf("jira: get current user failed: missing accountId field")
+	}
+	return &user, nil
 }
 
 func basicAuth(email, token string) string {
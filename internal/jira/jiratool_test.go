@@ -1,13 +1,18 @@
 package jira
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/ledzpl/pcl/internal/adf"
 )
 
 func TestBasicAuth(t *testing.T) {
@@ -22,8 +27,18 @@ func TestBasicAuth(t *testing.T) {
 	}
 }
 
+func validPayload() *IssuePayload {
+	return &IssuePayload{Fields: IssueFields{
+		Project:     ProjectRef{Key: "PCL"},
+		Summary:     "diff 필터 추가",
+		IssueType:   IssueTypeRef{Name: "Task"},
+		Description: adf.Doc(adf.Paragraph("잠금 파일을 제외한다")),
+	}}
+}
+
 func TestCreateIssue(t *testing.T) {
-	reqBody := `{"fields":{}}`
+	payload := validPayload()
+	reqBody := `{"fields":{"project":{"key":"PCL"},"summary":"diff 필터 추가","issuetype":{"name":"Task"},"description":{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"잠금 파일을 제외한다"}]}]}}}`
 
 	var received struct {
 		method string
@@ -44,17 +59,23 @@ func TestCreateIssue(t *testing.T) {
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
-		_, _ = w.Write([]byte(`{"id":"1000"}`))
+		_, _ = w.Write([]byte(`{"id":"1000","key":"PCL-7","self":"https://example.atlassian.net/rest/api/3/issue/1000"}`))
 	}))
 	defer ts.Close()
 
 	email := "user@example.com"
 	token := "token123"
 
-	if err := CreateIssue(reqBody, email, ts.URL, token); err != nil {
+	created, err := NewClient(Options{Host: ts.URL, Email: email, Token: token}).CreateIssue(context.Background(), payload)
+	if err != nil {
 		t.Fatalf("CreateIssue() unexpected error: %v", err)
 	}
 
+	want := CreatedIssue{ID: "1000", Key: "PCL-7", Self: "https://example.atlassian.net/rest/api/3/issue/1000"}
+	if *created != want {
+		t.Fatalf("CreateIssue() = %+v, want %+v", *created, want)
+	}
+
 	if received.method != http.MethodPost {
 		t.Fatalf("CreateIssue did not POST: got %s", received.method)
 	}
@@ -92,7 +113,7 @@ func TestCreateIssueReturnsErrorOnHTTPFailure(t *testing.T) {
 	email := "user@example.com"
 	token := "token123"
 
-	err := CreateIssue(`{"fields":{}}`, email, ts.URL, token)
+	_, err := NewClient(Options{Host: ts.URL, Email: email, Token: token}).CreateIssue(context.Background(), validPayload())
 	if err == nil {
 		t.Fatalf("CreateIssue() expected error for HTTP 400")
 	}
@@ -101,7 +122,123 @@ func TestCreateIssueReturnsErrorOnHTTPFailure(t *testing.T) {
 	}
 }
 
-func TestGetAccountId(t *testing.T) {
+func TestCreateIssueRejectsInvalidPayloadLocally(t *testing.T) {
+	called := false
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer ts.Close()
+
+	payload := validPayload()
+	payload.Fields.IssueType.Name = "Bug"
+
+	_, err := newTestClient(ts.URL).CreateIssue(context.Background(), payload)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("CreateIssue() error = %v, want *ValidationError", err)
+	}
+	if called {
+		t.Fatal("CreateIssue() sent an invalid payload to Jira")
+	}
+}
+
+func TestCreateIssueMissingKey(t *testing.T) {
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1000"}`))
+	}))
+	defer ts.Close()
+
+	if _, err := newTestClient(ts.URL).CreateIssue(context.Background(), validPayload()); err == nil || !strings.Contains(err.Error(), "missing key") {
+		t.Fatalf("CreateIssue() error = %v, want missing key error", err)
+	}
+}
+
+func TestDataCenterClient(t *testing.T) {
+	var received []struct {
+		path string
+		auth string
+		body map[string]map[string]any
+	}
+
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entry := struct {
+			path string
+			auth string
+			body map[string]map[string]any
+		}{path: r.URL.Path, auth: r.Header.Get("Authorization")}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(r.Body).Decode(&entry.body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+		}
+		received = append(received, entry)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/rest/api/2/myself" {
+			_, _ = w.Write([]byte(`{"name":"jdoe","displayName":"John Doe"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"10","key":"OPS-1","self":"https://jira.example.com/rest/api/2/issue/10"}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(Options{Host: ts.URL, Deployment: DeploymentDataCenter, Token: "pat-123"})
+
+	user, err := c.CurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentUser() error: %v", err)
+	}
+	if user.ID() != "jdoe" || user.Ref().Name != "jdoe" || user.Ref().AccountID != "" {
+		t.Fatalf("CurrentUser() = %+v, want name jdoe", user)
+	}
+
+	payload := validPayload()
+	payload.Fields.Assignee = user.Ref()
+	if _, err := c.CreateIssue(context.Background(), payload); err != nil {
+		t.Fatalf("CreateIssue() error: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("requests = %d, want 2", len(received))
+	}
+	for _, r := range received {
+		if r.auth != "Bearer pat-123" {
+			t.Fatalf("Authorization = %q, want bearer PAT", r.auth)
+		}
+	}
+	if received[1].path != "/rest/api/2/issue" {
+		t.Fatalf("create path = %s, want /rest/api/2/issue", received[1].path)
+	}
+	fields := received[1].body["fields"]
+	if fields["description"] != "잠금 파일을 제외한다" {
+		t.Fatalf("description = %#v, want wiki markup string", fields["description"])
+	}
+	if assignee, _ := fields["assignee"].(map[string]any); assignee["name"] != "jdoe" {
+		t.Fatalf("assignee = %#v, want name jdoe", fields["assignee"])
+	}
+}
+
+func TestBrowseURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"https://example.atlassian.net", "https://example.atlassian.net/browse/PCL-7"},
+		{"https://example.atlassian.net/", "https://example.atlassian.net/browse/PCL-7"},
+		{"https://jira.example.com/jira", "https://jira.example.com/jira/browse/PCL-7"},
+	}
+
+	for _, tt := range tests {
+		if got := BrowseURL(tt.host, "PCL-7"); got != tt.want {
+			t.Fatalf("BrowseURL(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentUser(t *testing.T) {
 	const accountID = "abc-123"
 
 	var received http.Header
@@ -125,12 +262,12 @@ func TestGetAccountId(t *testing.T) {
 	email := "user@example.com"
 	token := "token123"
 
-	got, err := GetAccountId(email, ts.URL, token)
+	got, err := NewClient(Options{Host: ts.URL, Email: email, Token: token}).CurrentUser(context.Background())
 	if err != nil {
-		t.Fatalf("GetAccountId() unexpected error: %v", err)
+		t.Fatalf("CurrentUser() unexpected error: %v", err)
 	}
-	if got != accountID {
-		t.Fatalf("GetAccountId() = %q, want %q", got, accountID)
+	if got.AccountID != accountID || got.ID() != accountID || got.Ref().AccountID != accountID {
+		t.Fatalf("CurrentUser() = %+v, want account %q", got, accountID)
 	}
 
 	expectedAuth := basicAuth(email, token)
@@ -143,7 +280,7 @@ func TestGetAccountId(t *testing.T) {
 	}
 }
 
-func TestGetAccountIdReturnsErrorOnHTTPFailure(t *testing.T) {
+func TestCurrentUserReturnsErrorOnHTTPFailure(t *testing.T) {
 	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusUnauthorized)
@@ -154,16 +291,16 @@ func TestGetAccountIdReturnsErrorOnHTTPFailure(t *testing.T) {
 	email := "user@example.com"
 	token := "token123"
 
-	_, err := GetAccountId(email, ts.URL, token)
+	_, err := NewClient(Options{Host: ts.URL, Email: email, Token: token}).CurrentUser(context.Background())
 	if err == nil {
-		t.Fatalf("GetAccountId() expected error for HTTP 401")
+		t.Fatalf("CurrentUser() expected error for HTTP 401")
 	}
 	if !strings.Contains(err.Error(), "status 401") {
-		t.Fatalf("GetAccountId() error %q missing status code", err)
+		t.Fatalf("CurrentUser() error %q missing status code", err)
 	}
 }
 
-func TestGetAccountIdMissingField(t *testing.T) {
+func TestCurrentUserMissingField(t *testing.T) {
 	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = w.Write([]byte(`{}`))
@@ -173,13 +310,118 @@ func TestGetAccountIdMissingField(t *testing.T) {
 	email := "user@example.com"
 	token := "token123"
 
-	_, err := GetAccountId(email, ts.URL, token)
+	_, err := NewClient(Options{Host: ts.URL, Email: email, Token: token}).CurrentUser(context.Background())
 	if err == nil {
-		t.Fatalf("GetAccountId() expected error when accountId missing")
+		t.Fatalf("CurrentUser() expected error when accountId missing")
 	}
 	if !strings.Contains(err.Error(), "missing accountId") {
-		t.Fatalf("GetAccountId() error %q missing expected message", err)
+		t.Fatalf("CurrentUser() error %q missing expected message", err)
+	}
+}
+
+func TestGetIssue(t *testing.T) {
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/3/issue/PCL-404" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["Issue does not exist or you do not have permission to see it."]}`))
+			return
+		}
+		if r.URL.Path != "/rest/api/3/issue/PCL-123" || r.URL.Query().Get("fields") != "summary,issuetype,status,description" {
+			t.Fatalf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"id":"1","key":"PCL-123","fields":{"summary":"재시도 로직","issuetype":{"name":"Story"},"status":{"name":"In Progress"}}}`))
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts.URL)
+	issue, err := client.GetIssue(context.Background(), "PCL-123")
+	if err != nil {
+		t.Fatalf("GetIssue() error: %v", err)
+	}
+	if issue.Key != "PCL-123" || issue.Fields.Summary != "재시도 로직" || issue.Fields.IssueType.Name != "Story" || issue.Fields.Status.Name != "In Progress" {
+		t.Fatalf("GetIssue() = %+v", issue)
 	}
+
+	if _, err := client.GetIssue(context.Background(), "PCL-404"); !IsNotFound(err) {
+		t.Fatalf("GetIssue() error = %v, want not found", err)
+	}
+	if IsNotFound(errors.New("boom")) {
+		t.Fatal("IsNotFound() = true for a non-API error")
+	}
+}
+
+func TestUpdateDescription(t *testing.T) {
+	doc := adf.Doc(adf.Heading(3, "배경"), adf.Paragraph("재시도 추가"))
+
+	tests := []struct {
+		name       string
+		deployment Deployment
+		path       string
+		want       string
+	}{
+		{"cloud", DeploymentCloud, "/rest/api/3/issue/PCL-7", `{"fields":{"description":{"type":"doc","version":1,"content":[{"type":"heading","attrs":{"level":3},"content":[{"type":"text","text":"배경"}]},{"type":"paragraph","content":[{"type":"text","text":"재시도 추가"}]}]}}}`},
+		{"dataCenter", DeploymentDataCenter, "/rest/api/2/issue/PCL-7", `{"fields":{"description":"h3. 배경\n\n재시도 추가"}}`},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			var body string
+			ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut || r.URL.Path != caseData.path {
+					t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer ts.Close()
+
+			client := NewClient(Options{Host: ts.URL, Deployment: caseData.deployment, Email: "user@example.com", Token: "token123"})
+			if err := client.UpdateDescription(context.Background(), "PCL-7", doc); err != nil {
+				t.Fatalf("UpdateDescription() error: %v", err)
+			}
+			if body != caseData.want {
+				t.Fatalf("body = %s, want %s", body, caseData.want)
+			}
+		})
+	}
+
+	if err := newTestClient("http://127.0.0.1:1").UpdateDescription(context.Background(), "PCL-7", adf.Doc(&adf.Node{Type: "table"})); err == nil {
+		t.Fatal("UpdateDescription() expected local validation error")
+	}
+}
+
+func TestIssueDescription(t *testing.T) {
+	cloud := IssueSummary{Description: json.RawMessage(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"본문"}]}]}`)}
+	if got := cloud.DescriptionText(); got != "본문\n" {
+		t.Fatalf("DescriptionText() cloud = %q", got)
+	}
+	if got := cloud.DescriptionSource(); !strings.HasPrefix(got, `{"type":"doc"`) {
+		t.Fatalf("DescriptionSource() cloud = %q", got)
+	}
+
+	dc := IssueSummary{Description: json.RawMessage(`"h3. 배경\n본문"`)}
+	if got := dc.DescriptionText(); got != "h3. 배경\n본문" {
+		t.Fatalf("DescriptionText() data center = %q", got)
+	}
+
+	empty := IssueSummary{Description: json.RawMessage(`null`)}
+	if empty.DescriptionSource() != "" || empty.DescriptionText() != "" {
+		t.Fatal("empty description should render as empty string")
+	}
+
+	rich := IssueSummary{Description: json.RawMessage(`{"type":"doc","version":1,"content":[{"type":"panel","content":[{"type":"paragraph","content":[{"type":"mention","attrs":{"id":"abc"}}]}]}]}`)}
+	if got := strings.Join(rich.UnsupportedDescriptionNodes(), ","); got != "mention,panel" {
+		t.Fatalf("UnsupportedDescriptionNodes() = %q, want mention,panel", got)
+	}
+	if cloud.UnsupportedDescriptionNodes() != nil || dc.UnsupportedDescriptionNodes() != nil || empty.UnsupportedDescriptionNodes() != nil {
+		t.Fatal("UnsupportedDescriptionNodes() should be nil for supported, wiki and empty descriptions")
+	}
+}
+
+func newTestClient(host string) *Client {
+	return NewClient(Options{Host: host, Email: "user@example.com", Token: "token123"})
 }
 
 func newIPv4Server(t *testing.T, handler http.Handler) *httptest.Server {
@@ -0,0 +1,133 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[0-9]+$`)
+
+// ValidIssueKey는 key가 PCL-123 형태의 이슈 키인지 확인한다.
+func ValidIssueKey(key string) bool {
+	return issueKeyPattern.MatchString(key)
+}
+
+// IssueRef는 다른 이슈 참조다.
+type IssueRef struct {
+	Key string `json:"key"`
+}
+
+// Link는 새로 만든 이슈와 기존 이슈 Key 사이의 관계다.
+// Type은 Jira 이슈 링크 타입 이름(Relates, Blocks, Duplicate)이고,
+// Inward가 true면 관계 방향이 반대다 (예: "새 이슈 is blocked by Key").
+type Link struct {
+	Type   string
+	Key    string
+	Inward bool
+}
+
+type linkAlias struct {
+	name   string
+	typ    string
+	inward bool
+}
+
+// linkAliases는 -link에서 받는 관계 이름이다. 앞의 이름이 대표 이름이다.
+var linkAliases = []linkAlias{
+	{"relates", "Relates", false},
+	{"relates-to", "Relates", false},
+	{"blocks", "Blocks", false},
+	{"is-blocked-by", "Blocks", true},
+	{"blocked-by", "Blocks", true},
+	{"duplicates", "Duplicate", false},
+	{"is-duplicated-by", "Duplicate", true},
+	{"duplicated-by", "Duplicate", true},
+}
+
+// LinkNames는 ParseLink가 받는 관계 이름 목록이다.
+func LinkNames() []string {
+	names := make([]string, len(linkAliases))
+	for i, a := range linkAliases {
+		names[i] = a.name
+	}
+	return names
+}
+
+// ParseLink는 "blocks:PCL-12" 형태의 값을 읽는다. 관계를 생략하면 relates다.
+func ParseLink(s string) (Link, error) {
+	name, key, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		name, key = "relates", name
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+	key = strings.ToUpper(strings.TrimSpace(key))
+
+	i := slices.IndexFunc(linkAliases, func(a linkAlias) bool { return a.name == name })
+	if i < 0 {
+		return Link{}, fmt.Errorf("jira: unknown link type %q (want one of %s)", name, strings.Join(LinkNames(), ", "))
+	}
+	if !ValidIssueKey(key) {
+		return Link{}, fmt.Errorf("jira: invalid issue key %q in link %q", key, s)
+	}
+	return Link{Type: linkAliases[i].typ, Key: key, Inward: linkAliases[i].inward}, nil
+}
+
+// String은 ParseLink로 다시 읽을 수 있는 "blocks:PCL-12" 형태다.
+func (l Link) String() string {
+	for _, a := range linkAliases {
+		if a.typ == l.Type && a.inward == l.Inward {
+			return a.name + ":" + l.Key
+		}
+	}
+	return l.Type + ":" + l.Key
+}
+
+// LinkIssue는 from 이슈와 link.Key 이슈를 link.Type 관계로 연결한다.
+// Jira는 inwardIssue에서 outwardIssue 쪽으로 링크 타입의 outward 설명(blocks 등)을 붙인다.
+func (c *Client) LinkIssue(ctx context.Context, from string, link Link) error {
+	inward, outward := from, link.Key
+	if link.Inward {
+		inward, outward = outward, inward
+	}
+	body := map[string]any{
+		"type":         map[string]string{"name": link.Type},
+		"inwardIssue":  IssueRef{Key: inward},
+		"outwardIssue": IssueRef{Key: outward},
+	}
+	return c.do(ctx, "link issues", http.MethodPost, c.apiPath("/issueLink"), body, nil)
+}
+
+// epicLinkSchema는 Data Center의 Epic Link 커스텀 필드 형식이다.
+const epicLinkSchema = "com.pyxis.greenhopper.jira:gh-epic-link"
+
+// withEpicLink는 Data Center에서 하위 작업이 아닌 이슈의 parent를 Epic Link 필드로 옮긴 복사본을 반환한다.
+// Data Center의 parent는 하위 작업에만 쓸 수 있고, 에픽은 Epic Link 커스텀 필드로 지정한다.
+// 메타데이터에 Epic Link 필드가 없으면 payload를 그대로 반환한다.
+func withEpicLink(payload *IssuePayload, meta *ProjectMeta) *IssuePayload {
+	if payload.Fields.Parent == nil || meta == nil {
+		return payload
+	}
+	t, ok := meta.IssueType(payload.Fields.IssueType.Name)
+	if !ok || t.Subtask {
+		return payload
+	}
+	i := slices.IndexFunc(t.Fields, func(f FieldMeta) bool { return f.Schema.Custom == epicLinkSchema })
+	if i < 0 {
+		return payload
+	}
+
+	key, _ := json.Marshal(payload.Fields.Parent.Key)
+	out := *payload
+	out.Fields.Parent = nil
+	out.Fields.Extra = map[string]json.RawMessage{}
+	for k, v := range payload.Fields.Extra {
+		out.Fields.Extra[k] = v
+	}
+	out.Fields.Extra[t.Fields[i].Key] = key
+	return &out
+}
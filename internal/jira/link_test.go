@@ -0,0 +1,165 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Link
+		wantErr bool
+	}{
+		{"relates", "relates:PCL-3", Link{Type: "Relates", Key: "PCL-3"}, false},
+		{"defaultRelates", "PCL-3", Link{Type: "Relates", Key: "PCL-3"}, false},
+		{"blocks", "blocks:PCL-12", Link{Type: "Blocks", Key: "PCL-12"}, false},
+		{"blockedBy", " Blocked-By : pcl-12 ", Link{Type: "Blocks", Key: "PCL-12", Inward: true}, false},
+		{"duplicates", "duplicates:OPS_2-1", Link{Type: "Duplicate", Key: "OPS_2-1"}, false},
+		{"unknownType", "causes:PCL-1", Link{}, true},
+		{"badKey", "blocks:PCL", Link{}, true},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			got, err := ParseLink(caseData.input)
+			if caseData.wantErr {
+				if err == nil {
+					t.Fatalf("ParseLink(%q) = %+v, want error", caseData.input, got)
+				}
+				return
+			}
+			if err != nil || got != caseData.want {
+				t.Fatalf("ParseLink(%q) = %+v, %v; want %+v", caseData.input, got, err, caseData.want)
+			}
+		})
+	}
+}
+
+func TestLinkIssue(t *testing.T) {
+	var received []string
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issueLink" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, string(body))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts.URL)
+	if err := client.LinkIssue(context.Background(), "PCL-20", Link{Type: "Blocks", Key: "PCL-12"}); err != nil {
+		t.Fatalf("LinkIssue() error: %v", err)
+	}
+	if err := client.LinkIssue(context.Background(), "PCL-20", Link{Type: "Blocks", Key: "PCL-12", Inward: true}); err != nil {
+		t.Fatalf("LinkIssue() inward error: %v", err)
+	}
+
+	want := []string{
+		`{"inwardIssue":{"key":"PCL-20"},"outwardIssue":{"key":"PCL-12"},"type":{"name":"Blocks"}}`,
+		`{"inwardIssue":{"key":"PCL-12"},"outwardIssue":{"key":"PCL-20"},"type":{"name":"Blocks"}}`,
+	}
+	for i := range want {
+		if received[i] != want[i] {
+			t.Fatalf("request %d body = %s, want %s", i, received[i], want[i])
+		}
+	}
+}
+
+func TestLinkIssueReturnsAPIError(t *testing.T) {
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errorMessages":["No issue link type with name 'Blocks' found."]}`))
+	}))
+	defer ts.Close()
+
+	err := newTestClient(ts.URL).LinkIssue(context.Background(), "PCL-20", Link{Type: "Blocks", Key: "PCL-12"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("LinkIssue() error = %v, want 404 *APIError", err)
+	}
+}
+
+func TestCreateIssueSendsParent(t *testing.T) {
+	var body map[string]map[string]json.RawMessage
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":"1","key":"PCL-21"}`))
+	}))
+	defer ts.Close()
+
+	payload := validPayload()
+	payload.Fields.Parent = &IssueRef{Key: "PCL-10"}
+	if _, err := newTestClient(ts.URL).CreateIssue(context.Background(), payload); err != nil {
+		t.Fatalf("CreateIssue() error: %v", err)
+	}
+	if got := string(body["fields"]["parent"]); got != `{"key":"PCL-10"}` {
+		t.Fatalf("parent = %s, want {\"key\":\"PCL-10\"}", got)
+	}
+
+	payload.Fields.Parent = &IssueRef{Key: "epic"}
+	_, err := newTestClient(ts.URL).CreateIssue(context.Background(), payload)
+	if err == nil || !strings.Contains(err.Error(), "fields.parent.key") {
+		t.Fatalf("CreateIssue() error = %v, want fields.parent.key problem", err)
+	}
+}
+
+func TestDataCenterSendsEpicLink(t *testing.T) {
+	var body map[string]map[string]json.RawMessage
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/issue/createmeta/PCL/issuetypes":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"3","name":"Task"},{"id":"5","name":"Sub-task","subtask":true}]}`))
+		case "/rest/api/2/issue/createmeta/PCL/issuetypes/3":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"fieldId":"customfield_10008","name":"Epic Link","schema":{"type":"any","custom":"com.pyxis.greenhopper.jira:gh-epic-link"}}]}`))
+		case "/rest/api/2/issue/createmeta/PCL/issuetypes/5":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[]}`))
+		case "/rest/api/2/issue":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"id":"1","key":"PCL-21"}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(Options{Host: ts.URL, Deployment: DeploymentDataCenter, Token: "pat"})
+	if _, err := client.ProjectMeta(context.Background(), "PCL"); err != nil {
+		t.Fatalf("ProjectMeta() error: %v", err)
+	}
+
+	payload := validPayload()
+	payload.Fields.Parent = &IssueRef{Key: "PCL-10"}
+	if _, err := client.CreateIssue(context.Background(), payload); err != nil {
+		t.Fatalf("CreateIssue() error: %v", err)
+	}
+	if _, ok := body["fields"]["parent"]; ok {
+		t.Fatal("Data Center request should not send parent for a non sub-task issue")
+	}
+	if got := string(body["fields"]["customfield_10008"]); got != `"PCL-10"` {
+		t.Fatalf("epic link = %s, want \"PCL-10\"", got)
+	}
+	if payload.Fields.Parent == nil || payload.Fields.Extra != nil {
+		t.Fatal("CreateIssue() modified the caller's payload")
+	}
+
+	subtask := validPayload()
+	subtask.Fields.IssueType.Name = "Sub-task"
+	_, err := client.CreateIssue(context.Background(), subtask)
+	if err == nil || !strings.Contains(err.Error(), "fields.parent") {
+		t.Fatalf("CreateIssue() error = %v, want missing parent for sub-task", err)
+	}
+}
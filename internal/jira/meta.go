@@ -0,0 +1,324 @@
+package jira
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultMetaCacheTTL은 프로젝트 메타데이터 캐시의 기본 유효 기간이다.
+const DefaultMetaCacheTTL = 24 * time.Hour
+
+// ProjectMeta는 createmeta로 조회한 프로젝트의 이슈 타입과 필드 정보다.
+type ProjectMeta struct {
+	Project    string          `json:"project"`
+	IssueTypes []IssueTypeMeta `json:"issueTypes"`
+	FetchedAt  time.Time       `json:"fetchedAt"`
+}
+
+// IssueTypeMeta는 이슈 타입 하나와 생성 화면의 필드 목록이다.
+type IssueTypeMeta struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Subtask     bool        `json:"subtask"`
+	Fields      []FieldMeta `json:"fields"`
+}
+
+// FieldMeta는 이슈 생성 필드 하나다.
+type FieldMeta struct {
+	Key           string         `json:"key"`
+	Name          string         `json:"name"`
+	Required      bool           `json:"required"`
+	HasDefault    bool           `json:"hasDefaultValue"`
+	Schema        FieldSchema    `json:"schema"`
+	AllowedValues []AllowedValue `json:"allowedValues,omitempty"`
+}
+
+// AllowedLabels는 허용 값의 이름 목록이다.
+func (f FieldMeta) AllowedLabels() []string {
+	labels := make([]string, len(f.AllowedValues))
+	for i, v := range f.AllowedValues {
+		labels[i] = v.Label()
+	}
+	return labels
+}
+
+// FieldSchema는 필드 값의 형식이다 (예: type "array", items "option").
+type FieldSchema struct {
+	Type   string `json:"type"`
+	Items  string `json:"items,omitempty"`
+	System string `json:"system,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
+
+// AllowedValue는 선택형 필드에 허용되는 값이다. 필드에 따라 Name 또는 Value가 채워진다.
+type AllowedValue struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Label은 사람이 읽는 값 이름이다.
+func (v AllowedValue) Label() string {
+	if v.Name != "" {
+		return v.Name
+	}
+	if v.Value != "" {
+		return v.Value
+	}
+	return v.ID
+}
+
+// matches는 ref가 이 값을 가리키는지 확인한다. id, name, value 중 하나라도 같으면 같은 값이다.
+func (v AllowedValue) matches(ref AllowedValue) bool {
+	return (ref.ID != "" && ref.ID == v.ID) ||
+		(ref.Name != "" && (ref.Name == v.Name || ref.Name == v.Value)) ||
+		(ref.Value != "" && (ref.Value == v.Value || ref.Value == v.Name))
+}
+
+// pcl이 직접 채우거나 Jira가 기본값을 넣는 필드라 필수 여부를 따로 검사하지 않는다.
+var managedFields = []string{"project", "issuetype", "summary", "description", "reporter", "assignee", "parent"}
+
+// IssueType은 name과 일치하는 이슈 타입을 찾는다.
+func (m *ProjectMeta) IssueType(name string) (IssueTypeMeta, bool) {
+	for _, t := range m.IssueTypes {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return IssueTypeMeta{}, false
+}
+
+// TypeNames는 하위 작업이 아닌 이슈 타입 이름 목록이다.
+func (m *ProjectMeta) TypeNames() []string {
+	names := make([]string, 0, len(m.IssueTypes))
+	for _, t := range m.IssueTypes {
+		if !t.Subtask {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// DefaultIssueType은 모델 없이 이슈를 만들 때 쓸 타입이다. Task가 있으면 Task, 없으면 첫 타입이다.
+func (m *ProjectMeta) DefaultIssueType() string {
+	names := m.TypeNames()
+	if slices.Contains(names, "Task") || len(names) == 0 {
+		return "Task"
+	}
+	return names[0]
+}
+
+// RequiredFields는 issueType을 만들 때 사용자가(또는 모델이) 채워야 하는 필수 필드다.
+func (t IssueTypeMeta) RequiredFields() []FieldMeta {
+	var out []FieldMeta
+	for _, f := range t.Fields {
+		if f.Required && !f.HasDefault && !slices.Contains(managedFields, f.Key) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Field는 key와 일치하는 필드를 찾는다.
+func (t IssueTypeMeta) Field(key string) (FieldMeta, bool) {
+	for _, f := range t.Fields {
+		if f.Key == key {
+			return f, true
+		}
+	}
+	return FieldMeta{}, false
+}
+
+// createmeta 응답은 Cloud와 Data Center가 목록 키 이름을 다르게 쓴다 (issueTypes/fields 또는 values).
+type metaPage[T any] struct {
+	IssueTypes []T   `json:"issueTypes"`
+	Fields     []T   `json:"fields"`
+	Values     []T   `json:"values"`
+	StartAt    int   `json:"startAt"`
+	MaxResults int   `json:"maxResults"`
+	Total      int   `json:"total"`
+	IsLast     *bool `json:"isLast"`
+}
+
+func (p metaPage[T]) items() []T {
+	switch {
+	case len(p.IssueTypes) > 0:
+		return p.IssueTypes
+	case len(p.Fields) > 0:
+		return p.Fields
+	}
+	return p.Values
+}
+
+func (p metaPage[T]) last(fetched int) bool {
+	if p.IsLast != nil {
+		return *p.IsLast
+	}
+	return len(p.items()) == 0 || fetched >= p.Total
+}
+
+// fetchPages는 startAt을 늘려 가며 createmeta·agile 목록을 끝까지 읽는다. path에 쿼리가 있어도 된다.
+func fetchPages[T any](ctx context.Context, c *Client, op, path string) ([]T, error) {
+	const pageSize = 50
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	var all []T
+	for {
+		q := url.Values{"startAt": {fmt.Sprint(len(all))}, "maxResults": {fmt.Sprint(pageSize)}}
+		var page metaPage[T]
+		if err := c.do(ctx, op, http.MethodGet, path+sep+q.Encode(), nil, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.items()...)
+		if page.last(len(all)) {
+			return all, nil
+		}
+	}
+}
+
+type rawFieldMeta struct {
+	FieldID       string            `json:"fieldId"`
+	Key           string            `json:"key"`
+	Name          string            `json:"name"`
+	Required      bool              `json:"required"`
+	HasDefault    bool              `json:"hasDefaultValue"`
+	Schema        FieldSchema       `json:"schema"`
+	AllowedValues []json.RawMessage `json:"allowedValues"`
+}
+
+// FetchProjectMeta는 createmeta 엔드포인트로 project의 이슈 타입과 필드를 조회한다.
+func (c *Client) FetchProjectMeta(ctx context.Context, project string) (*ProjectMeta, error) {
+	base := c.apiPath("/issue/createmeta/" + url.PathEscape(project) + "/issuetypes")
+
+	types, err := fetchPages[IssueTypeMeta](ctx, c, "get issue types", base)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &ProjectMeta{Project: project, FetchedAt: time.Now()}
+	for _, t := range types {
+		raw, err := fetchPages[rawFieldMeta](ctx, c, "get issue fields", base+"/"+url.PathEscape(t.ID))
+		if err != nil {
+			return nil, err
+		}
+		t.Fields = make([]FieldMeta, 0, len(raw))
+		for _, f := range raw {
+			key := f.FieldID
+			if key == "" {
+				key = f.Key
+			}
+			field := FieldMeta{Key: key, Name: f.Name, Required: f.Required, HasDefault: f.HasDefault, Schema: f.Schema}
+			for _, v := range f.AllowedValues {
+				var av AllowedValue
+				if json.Unmarshal(v, &av) == nil && av.Label() != "" {
+					field.AllowedValues = append(field.AllowedValues, av)
+				}
+			}
+			t.Fields = append(t.Fields, field)
+		}
+		meta.IssueTypes = append(meta.IssueTypes, t)
+	}
+	if len(meta.IssueTypes) == 0 {
+		return nil, fmt.Errorf("jira: get issue types failed: project %s has no creatable issue types", project)
+	}
+	return meta, nil
+}
+
+// MaxLabels는 Labels가 한 번에 읽는 라벨 수의 상한이다.
+const MaxLabels = 1000
+
+// Labels는 사이트에서 이미 쓰고 있는 라벨을 최대 MaxLabels개 조회한다.
+// Data Center에는 라벨 목록 API가 없어 빈 목록을 반환한다.
+func (c *Client) Labels(ctx context.Context) ([]string, error) {
+	if c.deployment == DeploymentDataCenter {
+		return nil, nil
+	}
+	var page struct {
+		Values []string `json:"values"`
+	}
+	path := c.apiPath("/label") + "?maxResults=" + fmt.Sprint(MaxLabels)
+	if err := c.do(ctx, "list labels", http.MethodGet, path, nil, &page); err != nil {
+		return nil, err
+	}
+	return page.Values, nil
+}
+
+// ProjectMeta는 project의 메타데이터를 반환한다. 캐시가 유효하면 캐시를 쓰고, 아니면 조회해 캐시에 저장한다.
+// 조회한 메타데이터는 이후 CreateIssue의 검증에도 사용된다.
+func (c *Client) ProjectMeta(ctx context.Context, project string) (*ProjectMeta, error) {
+	path := c.metaCachePath(project)
+	if meta, ok := readMetaCache(path, c.metaCacheTTL); ok {
+		c.metas[project] = meta
+		return meta, nil
+	}
+
+	meta, err := c.FetchProjectMeta(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	c.metas[project] = meta
+	if path != "" {
+		// 캐시 저장 실패는 다음 실행에서 다시 조회하면 되므로 무시한다.
+		_ = writeMetaCache(path, meta)
+	}
+	return meta, nil
+}
+
+// metaCachePath는 host와 project별 캐시 파일 경로다. 캐시를 쓰지 않으면 빈 문자열이다.
+func (c *Client) metaCachePath(project string) string {
+	if c.metaCacheDir == "" || c.metaCacheTTL <= 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(strings.TrimRight(c.host, "/")))
+	name := fmt.Sprintf("createmeta-%s-%s.json", hex.EncodeToString(sum[:6]), url.PathEscape(project))
+	return filepath.Join(c.metaCacheDir, name)
+}
+
+func readMetaCache(path string, ttl time.Duration) (*ProjectMeta, bool) {
+	if path == "" {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var meta ProjectMeta
+	if err := json.Unmarshal(data, &meta); err != nil || time.Since(meta.FetchedAt) > ttl || len(meta.IssueTypes) == 0 {
+		return nil, false
+	}
+	return &meta, true
+}
+
+func writeMetaCache(path string, meta *ProjectMeta) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// DefaultMetaCacheDir는 사용자 캐시 디렉터리 아래 pcl 디렉터리다.
+func DefaultMetaCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pcl")
+}
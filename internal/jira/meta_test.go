@@ -0,0 +1,223 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cloudCreatemeta는 Cloud 형식(issueTypes/fields, isLast 없음)으로 이슈 타입을 한 페이지에 하나씩 돌려준다.
+func cloudCreatemeta(t *testing.T, requests *atomic.Int32) http.HandlerFunc {
+	types := []string{
+		`{"id":"10001","name":"Task","subtask":false}`,
+		`{"id":"10002","name":"Bug","subtask":false}`,
+		`{"id":"10003","name":"Sub-task","subtask":true}`,
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/issue/createmeta/PCL/issuetypes":
+			start, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+			if start >= len(types) {
+				t.Fatalf("requested page past the end: startAt=%d", start)
+			}
+			fmt.Fprintf(w, `{"startAt":%d,"maxResults":1,"total":%d,"issueTypes":[%s]}`, start, len(types), types[start])
+		case "/rest/api/3/issue/createmeta/PCL/issuetypes/10002":
+			_, _ = w.Write([]byte(`{"startAt":0,"maxResults":50,"total":3,"fields":[
+				{"fieldId":"summary","name":"Summary","required":true,"schema":{"type":"string","system":"summary"}},
+				{"fieldId":"priority","name":"Priority","required":false,"hasDefaultValue":true,"schema":{"type":"priority"},"allowedValues":[{"id":"1","name":"High"},{"id":"2","name":"Low"}]},
+				{"fieldId":"customfield_10100","name":"Severity","required":true,"schema":{"type":"option","custom":"select"},"allowedValues":[{"id":"20","value":"S1"},{"id":"21","value":"S2"}]}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"startAt":0,"maxResults":50,"total":1,"fields":[{"fieldId":"summary","name":"Summary","required":true,"schema":{"type":"string"}}]}`))
+		}
+	}
+}
+
+func TestFetchProjectMeta(t *testing.T) {
+	var requests atomic.Int32
+	ts := newIPv4Server(t, cloudCreatemeta(t, &requests))
+	defer ts.Close()
+
+	meta, err := newTestClient(ts.URL).FetchProjectMeta(context.Background(), "PCL")
+	if err != nil {
+		t.Fatalf("FetchProjectMeta() error: %v", err)
+	}
+	// 이슈 타입 3페이지 + 타입별 필드 3번
+	if got := requests.Load(); got != 6 {
+		t.Fatalf("requests = %d, want 6", got)
+	}
+
+	if got := fmt.Sprint(meta.TypeNames()); got != "[Task Bug]" {
+		t.Fatalf("TypeNames() = %s, want [Task Bug]", got)
+	}
+	bug, ok := meta.IssueType("Bug")
+	if !ok {
+		t.Fatal("IssueType(Bug) not found")
+	}
+	required := bug.RequiredFields()
+	if len(required) != 1 || required[0].Key != "customfield_10100" {
+		t.Fatalf("RequiredFields() = %+v, want only customfield_10100", required)
+	}
+	if labels := []string{required[0].AllowedValues[0].Label(), required[0].AllowedValues[1].Label()}; fmt.Sprint(labels) != "[S1 S2]" {
+		t.Fatalf("allowed values = %v", labels)
+	}
+}
+
+func TestFetchProjectMetaDataCenter(t *testing.T) {
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/issue/createmeta/PCL/issuetypes":
+			_, _ = w.Write([]byte(`{"maxResults":50,"startAt":0,"total":1,"isLast":true,"values":[{"id":"3","name":"Task","subtask":false}]}`))
+		case "/rest/api/2/issue/createmeta/PCL/issuetypes/3":
+			_, _ = w.Write([]byte(`{"maxResults":50,"startAt":0,"total":1,"isLast":true,"values":[{"fieldId":"components","name":"Component/s","required":true,"schema":{"type":"array","items":"component"},"allowedValues":[{"id":"7","name":"cli"}]}]}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(Options{Host: ts.URL, Deployment: DeploymentDataCenter, Token: "pat"})
+	meta, err := client.FetchProjectMeta(context.Background(), "PCL")
+	if err != nil {
+		t.Fatalf("FetchProjectMeta() error: %v", err)
+	}
+	task, _ := meta.IssueType("Task")
+	if f, ok := task.Field("components"); !ok || !f.Required || f.AllowedValues[0].Name != "cli" {
+		t.Fatalf("components field = %+v, %v", f, ok)
+	}
+}
+
+func TestProjectMetaUsesCache(t *testing.T) {
+	var requests atomic.Int32
+	ts := newIPv4Server(t, cloudCreatemeta(t, &requests))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	opts := Options{Host: ts.URL, Email: "user@example.com", Token: "token123", MetaCacheDir: dir, MetaCacheTTL: time.Hour}
+
+	if _, err := NewClient(opts).ProjectMeta(context.Background(), "PCL"); err != nil {
+		t.Fatalf("ProjectMeta() error: %v", err)
+	}
+	fetched := requests.Load()
+
+	meta, err := NewClient(opts).ProjectMeta(context.Background(), "PCL")
+	if err != nil {
+		t.Fatalf("ProjectMeta() cached error: %v", err)
+	}
+	if requests.Load() != fetched {
+		t.Fatalf("cached ProjectMeta() sent %d more requests", requests.Load()-fetched)
+	}
+	if _, ok := meta.IssueType("Bug"); !ok {
+		t.Fatal("cached meta lost issue types")
+	}
+
+	// 유효 기간이 지난 캐시는 다시 조회한다.
+	client := NewClient(opts)
+	path := client.metaCachePath("PCL")
+	meta.FetchedAt = time.Now().Add(-2 * time.Hour)
+	if err := writeMetaCache(path, meta); err != nil {
+		t.Fatalf("writeMetaCache() error: %v", err)
+	}
+	if _, err := client.ProjectMeta(context.Background(), "PCL"); err != nil {
+		t.Fatalf("ProjectMeta() expired error: %v", err)
+	}
+	if requests.Load() != 2*fetched {
+		t.Fatalf("expired cache: requests = %d, want %d", requests.Load(), 2*fetched)
+	}
+}
+
+func TestCreateIssueValidatesAgainstProjectMeta(t *testing.T) {
+	var requests atomic.Int32
+	ts := newIPv4Server(t, cloudCreatemeta(t, &requests))
+	defer ts.Close()
+
+	client := newTestClient(ts.URL)
+	if _, err := client.ProjectMeta(context.Background(), "PCL"); err != nil {
+		t.Fatalf("ProjectMeta() error: %v", err)
+	}
+	fetched := requests.Load()
+
+	payload := validPayload()
+	payload.Fields.IssueType.Name = "Story"
+	_, err := client.CreateIssue(context.Background(), payload)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("CreateIssue() error = %v, want *ValidationError", err)
+	}
+	if requests.Load() != fetched {
+		t.Fatal("CreateIssue() sent a request for a payload the project does not accept")
+	}
+}
+
+func TestValidateForProjectMeta(t *testing.T) {
+	meta := &ProjectMeta{Project: "PCL", IssueTypes: []IssueTypeMeta{
+		{ID: "1", Name: "Task"},
+		{ID: "2", Name: "Bug", Fields: []FieldMeta{
+			{Key: "customfield_10100", Name: "Severity", Required: true, AllowedValues: []AllowedValue{{ID: "20", Value: "S1"}, {ID: "21", Value: "S2"}}},
+			{Key: "components", Name: "Component/s", AllowedValues: []AllowedValue{{ID: "7", Name: "cli"}}},
+		}},
+	}}
+
+	tests := []struct {
+		name   string
+		mutate func(p *IssuePayload)
+		path   string
+	}{
+		{"valid", func(p *IssuePayload) {}, ""},
+		{"unknownType", func(p *IssuePayload) { p.Fields.IssueType.Name = "Story" }, "fields.issuetype.name"},
+		{"missingRequired", func(p *IssuePayload) { p.Fields.IssueType.Name = "Bug" }, "fields.customfield_10100"},
+		{"requiredByID", func(p *IssuePayload) {
+			p.Fields.IssueType.Name = "Bug"
+			p.Fields.Extra = map[string]json.RawMessage{"customfield_10100": json.RawMessage(`{"id":"21"}`)}
+		}, ""},
+		{"disallowedValue", func(p *IssuePayload) {
+			p.Fields.IssueType.Name = "Bug"
+			p.Fields.Extra = map[string]json.RawMessage{"customfield_10100": json.RawMessage(`{"value":"S9"}`)}
+		}, "fields.customfield_10100"},
+		{"disallowedInArray", func(p *IssuePayload) {
+			p.Fields.IssueType.Name = "Bug"
+			p.Fields.Extra = map[string]json.RawMessage{
+				"customfield_10100": json.RawMessage(`{"value":"S1"}`),
+				"components":        json.RawMessage(`[{"name":"cli"},{"name":"web"}]`),
+			}
+		}, "fields.components"},
+		{"typedComponent", func(p *IssuePayload) {
+			p.Fields.IssueType.Name = "Bug"
+			p.Fields.Extra = map[string]json.RawMessage{"customfield_10100": json.RawMessage(`{"value":"S1"}`)}
+			p.Fields.Components = []NamedRef{{Name: "cli"}, {Name: "web"}}
+		}, "fields.components"},
+		{"labelWithSpace", func(p *IssuePayload) { p.Fields.Labels = []string{"backend", "needs review"} }, "fields.labels[1]"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			p := validPayload()
+			caseData.mutate(p)
+
+			err := p.ValidateFor(meta)
+			if caseData.path == "" {
+				if err != nil {
+					t.Fatalf("ValidateFor() error: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("ValidateFor() error = %v, want *ValidationError", err)
+			}
+			if len(verr.Problems) != 1 || verr.Problems[0].Path != caseData.path {
+				t.Fatalf("ValidateFor() problems = %+v, want single problem at %s", verr.Problems, caseData.path)
+			}
+		})
+	}
+}
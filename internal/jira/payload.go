@@ -0,0 +1,345 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ledzpl/pcl/internal/adf"
+)
+
+// MaxSummaryLength는 이슈 제목의 최대 글자 수(rune)다.
+const MaxSummaryLength = 80
+
+// IssueTypes는 pcl이 생성하는 이슈 타입이다.
+var IssueTypes = []string{"Story", "Task"}
+
+// IssuePayload는 POST /rest/api/3/issue 요청 본문이다.
+type IssuePayload struct {
+	Fields IssueFields `json:"fields"`
+}
+
+// IssueFields는 이슈 필드다. 알려지지 않은 필드(customfield_* 등)는 Extra에 원본 그대로 보관한다.
+type IssueFields struct {
+	Project     ProjectRef   `json:"project"`
+	Summary     string       `json:"summary"`
+	IssueType   IssueTypeRef `json:"issuetype"`
+	Assignee    *UserRef     `json:"assignee,omitempty"`
+	Description *adf.Node    `json:"description,omitempty"`
+	// Parent는 상위 이슈(에픽 또는 하위 작업의 부모)다.
+	Parent *IssueRef `json:"parent,omitempty"`
+	// Labels, Components, Priority, FixVersions는 설정·플래그의 기본값과 모델 제안을 합쳐 채운다.
+	Labels      []string   `json:"labels,omitempty"`
+	Components  []NamedRef `json:"components,omitempty"`
+	Priority    *NamedRef  `json:"priority,omitempty"`
+	FixVersions []NamedRef `json:"fixVersions,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// ProjectRef는 프로젝트 참조다.
+type ProjectRef struct {
+	Key string `json:"key"`
+}
+
+// IssueTypeRef는 이슈 타입 참조다.
+type IssueTypeRef struct {
+	Name string `json:"name"`
+}
+
+// NamedRef는 이름으로 가리키는 참조다 (컴포넌트, 우선순위, 버전).
+type NamedRef struct {
+	Name string `json:"name"`
+}
+
+// Names는 refs의 이름 목록이다.
+func Names(refs []NamedRef) []string {
+	names := make([]string, len(refs))
+	for i, r := range refs {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// UserRef는 사용자 참조다. Cloud는 AccountID, Data Center는 Name을 사용한다.
+type UserRef struct {
+	AccountID string `json:"accountId,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// ID는 참조가 가리키는 사용자 식별자다 (Account ID 또는 사용자 이름).
+func (r UserRef) ID() string {
+	if r.AccountID != "" {
+		return r.AccountID
+	}
+	return r.Name
+}
+
+// issueFields는 IssueFields의 기본 JSON 인코딩에 쓰는 별칭이다.
+type issueFields IssueFields
+
+var knownFields = []string{"project", "summary", "issuetype", "assignee", "description", "parent", "labels", "components", "priority", "fixVersions"}
+
+func (f IssueFields) MarshalJSON() ([]byte, error) {
+	known, err := json.Marshal(issueFields(f))
+	if err != nil || len(f.Extra) == 0 {
+		return known, err
+	}
+
+	merged := map[string]json.RawMessage{}
+	if err := json.Unmarshal(known, &merged); err != nil {
+		return nil, err
+	}
+	for k, v := range f.Extra {
+		if _, ok := merged[k]; !ok {
+			merged[k] = v
+		}
+	}
+	return json.Marshal(merged)
+}
+
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	var known issueFields
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, k := range knownFields {
+		delete(all, k)
+	}
+	if len(all) > 0 {
+		known.Extra = all
+	}
+
+	*f = IssueFields(known)
+	return nil
+}
+
+// wikiBody는 설명을 wiki markup 문자열로 바꾼 REST API v2용 요청 본문이다.
+func (p *IssuePayload) wikiBody() (map[string]map[string]json.RawMessage, error) {
+	fields := p.Fields
+	fields.Description = nil
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	if p.Fields.Description != nil {
+		desc, err := json.Marshal(adf.ToWiki(p.Fields.Description))
+		if err != nil {
+			return nil, err
+		}
+		out["description"] = desc
+	}
+	return map[string]map[string]json.RawMessage{"fields": out}, nil
+}
+
+// validateFields는 이슈 타입의 필수 필드가 모두 있는지, 선택형 필드 값이 허용 값 중 하나인지 검사한다.
+// pcl이 직접 채우는 필드(managedFields)는 검사하지 않는다.
+func validateFields(f IssueFields, t IssueTypeMeta) []FieldError {
+	raw, err := f.raw()
+	if err != nil {
+		return []FieldError{{Path: "fields", Message: err.Error()}}
+	}
+
+	var problems []FieldError
+	for _, field := range t.RequiredFields() {
+		if _, ok := raw[field.Key]; !ok {
+			problems = append(problems, FieldError{
+				Path:    "fields." + field.Key,
+				Message: fmt.Sprintf("%s is required for %s", field.Name, t.Name),
+			})
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(raw)) {
+		field, ok := t.Field(key)
+		if !ok || len(field.AllowedValues) == 0 || slices.Contains(managedFields, key) {
+			continue
+		}
+		if bad, ok := disallowedValue(raw[key], field.AllowedValues); !ok {
+			problems = append(problems, FieldError{
+				Path:    "fields." + key,
+				Message: fmt.Sprintf("%q is not an allowed value (allowed: %s)", bad, strings.Join(field.AllowedLabels(), ", ")),
+			})
+		}
+	}
+	return problems
+}
+
+// raw는 알려진 필드와 Extra를 합친 필드별 JSON 값이다. 비어 있어 생략되는 필드는 없다.
+func (f IssueFields) raw() (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// disallowedValue는 raw({"id"|"name"|"value": ...}, 문자열, 또는 그 배열)의 값이 모두 allowed에 있는지 검사한다.
+// 허용되지 않는 값이 있으면 그 값과 false를 반환한다.
+func disallowedValue(raw json.RawMessage, allowed []AllowedValue) (string, bool) {
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) != nil {
+		list = []json.RawMessage{raw}
+	}
+
+	for _, item := range list {
+		var ref AllowedValue
+		if json.Unmarshal(item, &ref) != nil {
+			var s string
+			if json.Unmarshal(item, &s) != nil {
+				continue
+			}
+			ref = AllowedValue{Value: s}
+		}
+		if !slices.ContainsFunc(allowed, func(v AllowedValue) bool { return v.matches(ref) }) {
+			return ref.Label(), false
+		}
+	}
+	return "", true
+}
+
+// FieldError는 페이로드 안의 위치(Path)와 위반 내용이다.
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError는 페이로드 검증에서 발견한 위반 목록이다.
+type ValidationError struct {
+	Problems []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.Error()
+	}
+	return "jira: invalid issue payload: " + strings.Join(msgs, "; ")
+}
+
+// ParseIssuePayload는 AI가 반환한 JSON 문자열을 IssuePayload로 읽는다. 검증은 하지 않는다.
+func ParseIssuePayload(s string) (*IssuePayload, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("jira: parse issue payload: empty response")
+	}
+	if s == "null" {
+		return nil, errors.New("jira: parse issue payload: response is null")
+	}
+
+	dec := json.NewDecoder(strings.NewReader(s))
+	var p IssuePayload
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("jira: parse issue payload: %w", describeJSONError(s, err))
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("jira: parse issue payload: unexpected data after JSON object")
+	}
+	return &p, nil
+}
+
+// describeJSONError는 문법 오류에 줄/열 위치를 덧붙인다.
+func describeJSONError(s string, err error) error {
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		before := []byte(s[:min(int(syntax.Offset), len(s))])
+		line := bytes.Count(before, []byte("\n")) + 1
+		col := len(before) - bytes.LastIndexByte(before, '\n')
+		return fmt.Errorf("line %d, column %d: %w", line, col, err)
+	}
+	return err
+}
+
+// Validate는 Jira에 보내기 전에 필수 필드, 제목 길이, 이슈 타입(Story/Task), 설명 ADF를 검사한다.
+// 위반이 있으면 *ValidationError를 반환한다.
+func (p *IssuePayload) Validate() error {
+	return p.ValidateFor(nil)
+}
+
+// ValidateFor는 Validate와 같지만, meta가 있으면 이슈 타입을 프로젝트에 실제로 있는 타입으로 검사하고
+// 필수 필드 누락과 선택형 필드의 허용 값도 검사한다.
+func (p *IssuePayload) ValidateFor(meta *ProjectMeta) error {
+	var problems []FieldError
+	add := func(path, format string, args ...any) {
+		problems = append(problems, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	f := p.Fields
+	if strings.TrimSpace(f.Project.Key) == "" {
+		add("fields.project.key", "must not be empty")
+	}
+
+	summary := strings.TrimSpace(f.Summary)
+	switch {
+	case summary == "":
+		add("fields.summary", "must not be empty")
+	case utf8.RuneCountInString(summary) > MaxSummaryLength:
+		add("fields.summary", "must be at most %d characters, got %d", MaxSummaryLength, utf8.RuneCountInString(summary))
+	case strings.ContainsAny(summary, "\r\n"):
+		add("fields.summary", "must be a single line")
+	}
+
+	if meta == nil {
+		if !slices.Contains(IssueTypes, f.IssueType.Name) {
+			add("fields.issuetype.name", "must be one of %s, got %q", strings.Join(IssueTypes, ", "), f.IssueType.Name)
+		}
+	} else if t, ok := meta.IssueType(f.IssueType.Name); !ok {
+		add("fields.issuetype.name", "must be one of %s, got %q", strings.Join(meta.TypeNames(), ", "), f.IssueType.Name)
+	} else {
+		problems = append(problems, validateFields(f, t)...)
+		if t.Subtask && f.Parent == nil {
+			add("fields.parent", "is required for sub-task type %s", t.Name)
+		}
+	}
+
+	if f.Parent != nil && !ValidIssueKey(f.Parent.Key) {
+		add("fields.parent.key", "must be an issue key like PCL-123, got %q", f.Parent.Key)
+	}
+	for i, l := range f.Labels {
+		if l == "" || strings.ContainsFunc(l, unicode.IsSpace) {
+			add(fmt.Sprintf("fields.labels[%d]", i), "must be a non-empty label without spaces, got %q", l)
+		}
+	}
+
+	if f.Description == nil {
+		add("fields.description", "must not be empty")
+	} else {
+		for _, e := range adf.Validate(f.Description) {
+			path := "fields.description"
+			if e.Path != "" {
+				path += "." + e.Path
+			}
+			add(path, "%s", e.Message)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
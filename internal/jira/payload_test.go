@@ -0,0 +1,132 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const samplePayload = `{
+  "fields": {
+    "project": { "key": "PCL" },
+    "summary": "diff 전송 전 민감 정보 가리기",
+    "issuetype": { "name": "Story" },
+    "assignee": { "accountId": "abc-123" },
+    "customfield_10010": 5,
+    "labels": ["security"],
+    "components": [{ "name": "cli" }],
+    "priority": { "name": "High" },
+    "description": {
+      "type": "doc",
+      "version": 1,
+      "content": [
+        { "type": "heading", "attrs": { "level": 3 }, "content": [{ "type": "text", "text": "배경" }] },
+        { "type": "paragraph", "content": [{ "type": "text", "text": "토큰이 AI로 전송된다", "marks": [{ "type": "strong" }] }] },
+        { "type": "bulletList", "content": [
+          { "type": "listItem", "content": [{ "type": "paragraph", "content": [{ "type": "text", "text": "정규식 규칙" }] }] }
+        ] },
+        { "type": "taskList", "attrs": { "localId": "b9d8a8a6-9b3a-4b4a-9e9b-3b4b1d2f3a4c" }, "content": [
+          { "type": "taskItem", "attrs": { "localId": "c1b2d3e4-f567-489a-9abc-0123456789ab", "state": "TODO" },
+            "content": [{ "type": "text", "text": "오탐 확인" }] }
+        ] }
+      ]
+    }
+  }
+}`
+
+func TestParseIssuePayloadValid(t *testing.T) {
+	p, err := ParseIssuePayload(samplePayload)
+	if err != nil {
+		t.Fatalf("ParseIssuePayload() error: %v", err)
+	}
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+
+	if p.Fields.Project.Key != "PCL" || p.Fields.IssueType.Name != "Story" || p.Fields.Assignee.AccountID != "abc-123" {
+		t.Fatalf("unexpected fields: %+v", p.Fields)
+	}
+	if len(p.Fields.Labels) != 1 || len(p.Fields.Components) != 1 || p.Fields.Priority == nil || p.Fields.Priority.Name != "High" {
+		t.Fatalf("labels/components/priority not parsed: %+v", p.Fields)
+	}
+	if _, ok := p.Fields.Extra["labels"]; ok || len(p.Fields.Extra) != 1 {
+		t.Fatalf("Extra = %v, want only customfield_10010", p.Fields.Extra)
+	}
+	if len(p.Fields.Description.Content) != 4 {
+		t.Fatalf("description nodes = %d, want 4", len(p.Fields.Description.Content))
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal() error: %v", err)
+	}
+	if !strings.Contains(string(out), `"customfield_10010":5`) {
+		t.Fatalf("custom field lost on round trip: %s", out)
+	}
+}
+
+func TestParseIssuePayloadErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "  ", "empty response"},
+		{"null", "null", "response is null"},
+		{"syntax", "{\n  \"fields\": {,}\n}", "line 2, column"},
+		{"trailing", `{"fields":{}} trailing`, "unexpected data"},
+		{"wrongType", `{"fields":{"summary":3}}`, "parse issue payload"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			_, err := ParseIssuePayload(caseData.input)
+			if err == nil || !strings.Contains(err.Error(), caseData.want) {
+				t.Fatalf("ParseIssuePayload() error = %v, want containing %q", err, caseData.want)
+			}
+		})
+	}
+}
+
+func TestValidateReportsPrecisePaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(p *IssuePayload)
+		path   string
+	}{
+		{"emptyProject", func(p *IssuePayload) { p.Fields.Project.Key = "" }, "fields.project.key"},
+		{"longSummary", func(p *IssuePayload) { p.Fields.Summary = strings.Repeat("가", MaxSummaryLength+1) }, "fields.summary"},
+		{"multilineSummary", func(p *IssuePayload) { p.Fields.Summary = "제목\n본문" }, "fields.summary"},
+		{"issueType", func(p *IssuePayload) { p.Fields.IssueType.Name = "Epic" }, "fields.issuetype.name"},
+		{"missingDescription", func(p *IssuePayload) { p.Fields.Description = nil }, "fields.description"},
+		{"disallowedNode", func(p *IssuePayload) { p.Fields.Description.Content[1].Type = "table" }, "fields.description.content[1].type"},
+		{"taskListLocalId", func(p *IssuePayload) { p.Fields.Description.Content[3].Attrs["localId"] = "task-1" }, "fields.description.content[3].attrs.localId"},
+		{"taskItemState", func(p *IssuePayload) {
+			p.Fields.Description.Content[3].Content[0].Attrs["state"] = "OPEN"
+		}, "fields.description.content[3].content[0].attrs.state"},
+		{"headingLevel", func(p *IssuePayload) { p.Fields.Description.Content[0].Attrs["level"] = float64(9) }, "fields.description.content[0].attrs.level"},
+		{"docVersion", func(p *IssuePayload) { p.Fields.Description.Version = 2 }, "fields.description.version"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			p, err := ParseIssuePayload(samplePayload)
+			if err != nil {
+				t.Fatalf("ParseIssuePayload() error: %v", err)
+			}
+			caseData.mutate(p)
+
+			err = p.Validate()
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			if len(verr.Problems) != 1 || verr.Problems[0].Path != caseData.path {
+				t.Fatalf("Validate() problems = %+v, want single problem at %s", verr.Problems, caseData.path)
+			}
+		})
+	}
+}
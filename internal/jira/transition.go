@@ -0,0 +1,65 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Transition은 이슈에 지금 적용할 수 있는 워크플로 전환이다.
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	To   struct {
+		Name string `json:"name"`
+	} `json:"to"`
+}
+
+// String은 "Start Progress → In Progress" 형태다. 전환 이름과 도착 상태가 같으면 하나만 쓴다.
+func (t Transition) String() string {
+	if t.To.Name == "" || strings.EqualFold(t.Name, t.To.Name) {
+		return t.Name
+	}
+	return t.Name + " → " + t.To.Name
+}
+
+// Transitions는 key 이슈의 현재 상태에서 적용할 수 있는 전환 목록을 조회한다.
+func (c *Client) Transitions(ctx context.Context, key string) ([]Transition, error) {
+	var result struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	path := c.apiPath("/issue/" + url.PathEscape(key) + "/transitions")
+	if err := c.do(ctx, "list transitions", http.MethodGet, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Transitions, nil
+}
+
+// TransitionIssue는 key 이슈에 id 전환을 적용한다.
+func (c *Client) TransitionIssue(ctx context.Context, key, id string) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("jira: transition issue failed: missing transition id")
+	}
+	body := map[string]any{"transition": map[string]string{"id": id}}
+	path := c.apiPath("/issue/" + url.PathEscape(key) + "/transitions")
+	return c.do(ctx, "transition issue", http.MethodPost, path, body, nil)
+}
+
+// FindTransition은 name과 전환 이름 또는 도착 상태 이름이 대소문자 구분 없이 같은 전환을 찾는다.
+// 전환 이름이 같은 것을 먼저 고르고, 없으면 도착 상태가 같은 것을 고른다.
+func FindTransition(transitions []Transition, name string) (Transition, bool) {
+	name = strings.TrimSpace(name)
+	for _, t := range transitions {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	for _, t := range transitions {
+		if strings.EqualFold(t.To.Name, name) {
+			return t, true
+		}
+	}
+	return Transition{}, false
+}
@@ -0,0 +1,79 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestTransitions(t *testing.T) {
+	var posted string
+	ts := newIPv4Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PCL-7/transitions" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"transitions":[{"id":"11","name":"Start Progress","to":{"name":"In Progress"}},{"id":"21","name":"Review","to":{"name":"In Review"}},{"id":"31","name":"Done","to":{"name":"Done"}}]}`))
+		case http.MethodPost:
+			data, _ := io.ReadAll(r.Body)
+			posted = string(data)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected method %s", r.Method)
+		}
+	}))
+	defer ts.Close()
+
+	client := newTestClient(ts.URL)
+	transitions, err := client.Transitions(context.Background(), "PCL-7")
+	if err != nil {
+		t.Fatalf("Transitions() error: %v", err)
+	}
+	if len(transitions) != 3 || transitions[0].String() != "Start Progress → In Progress" || transitions[2].String() != "Done" {
+		t.Fatalf("Transitions() = %+v", transitions)
+	}
+
+	if err := client.TransitionIssue(context.Background(), "PCL-7", "21"); err != nil {
+		t.Fatalf("TransitionIssue() error: %v", err)
+	}
+	if want := `{"transition":{"id":"21"}}`; posted != want {
+		t.Fatalf("body = %s, want %s", posted, want)
+	}
+	if err := client.TransitionIssue(context.Background(), "PCL-7", ""); err == nil {
+		t.Fatal("TransitionIssue() expected error for empty id")
+	}
+}
+
+func TestFindTransition(t *testing.T) {
+	transitions := []Transition{
+		{ID: "11", Name: "Start Progress"},
+		{ID: "21", Name: "Review"},
+		{ID: "41", Name: "In Review"},
+	}
+	transitions[0].To.Name = "In Progress"
+	transitions[1].To.Name = "In Review"
+	transitions[2].To.Name = "Blocked"
+
+	tests := []struct {
+		name   string
+		query  string
+		wantID string
+	}{
+		{"byTransitionName", "start progress", "11"},
+		{"byTargetStatus", "in progress", "11"},
+		{"transitionNameFirst", "In Review", "41"},
+		{"missing", "Done", ""},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			got, ok := FindTransition(transitions, caseData.query)
+			if ok != (caseData.wantID != "") || got.ID != caseData.wantID {
+				t.Fatalf("FindTransition(%q) = %+v, %v; want id %q", caseData.query, got, ok, caseData.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package redact
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	gittool "github.com/ledzpl/pcl/internal/git"
+)
+
+// Rule은 가릴 값을 찾는 정규식이다. 정규식에 "secret" 이름의 그룹이 있으면
+// 그 그룹만 가리고 키 이름 등 나머지 부분은 남긴다.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// 규칙 이름. Options.Disable에 지정해 끌 수 있다.
+const (
+	RulePrivateKey  = "private-key"
+	RuleHighEntropy = "high-entropy"
+	RuleEmail       = "email"
+)
+
+// DefaultRules는 흔히 유출되는 비밀 값 형식이다.
+var DefaultRules = []Rule{
+	{"aws-access-key", regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[0-9A-Z]{16}\b`)},
+	{"aws-secret-key", regexp.MustCompile(`(?i)aws_?(?:secret_?access_?key|secret)["']?\s*[:=]\s*["']?(?P<secret>[A-Za-z0-9/+=]{40})`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"github-token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{60,})\b`)},
+	{"slack-token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"api-key", regexp.MustCompile(`\b(?:sk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}|AIza[0-9A-Za-z_-]{35})`)},
+	{"password-assignment", regexp.MustCompile(`(?i)(?:password|passwd|pwd|secret|token|api_?key|access_?key|client_?secret)\w*["']?\s*[:=]+\s*["'](?P<secret>[^"'\s]{6,})["']`)},
+	{RuleEmail, regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)},
+}
+
+var (
+	privateKeyBegin = regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`)
+	privateKeyEnd   = regexp.MustCompile(`-----END [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`)
+	entropyToken    = regexp.MustCompile(`[A-Za-z0-9+/_=-]{24,}`)
+	hasDigit        = regexp.MustCompile(`[0-9]`)
+	hasLetter       = regexp.MustCompile(`[A-Za-z]`)
+)
+
+// DefaultEntropyThreshold는 high-entropy 규칙의 기본 Shannon 엔트로피(문자당 비트) 기준이다.
+const DefaultEntropyThreshold = 4.0
+
+// Options는 Redactor 설정이다.
+type Options struct {
+	// Patterns는 이름 → 정규식으로 추가하는 사용자 규칙이다.
+	Patterns map[string]string
+	// Disable은 끌 규칙 이름 목록이다 (예: "email", "high-entropy").
+	Disable []string
+	// EntropyThreshold가 0이면 DefaultEntropyThreshold를 사용한다.
+	EntropyThreshold float64
+}
+
+// Finding은 가린 값 하나의 위치다.
+type Finding struct {
+	Rule string
+	Path string
+}
+
+// Report는 가린 값의 요약이다.
+type Report struct {
+	Findings []Finding
+}
+
+// Total은 가린 값의 개수다.
+func (r Report) Total() int {
+	return len(r.Findings)
+}
+
+// Summary는 "규칙 N건" 목록과 파일 목록을 사람이 읽을 수 있는 문자열로 만든다.
+func (r Report) Summary() string {
+	if r.Total() == 0 {
+		return ""
+	}
+
+	counts := map[string]int{}
+	files := map[string]bool{}
+	for _, f := range r.Findings {
+		counts[f.Rule]++
+		files[f.Path] = true
+	}
+
+	rules := make([]string, 0, len(counts))
+	for name, n := range counts {
+		rules = append(rules, fmt.Sprintf("%s %d건", name, n))
+	}
+	sort.Strings(rules)
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	return fmt.Sprintf("민감 정보 %d건 (%s) — 파일: %s", r.Total(), strings.Join(rules, ", "), strings.Join(paths, ", "))
+}
+
+// Redactor는 diff 본문에서 비밀 값과 개인정보를 찾아 가린다.
+type Redactor struct {
+	rules      []Rule
+	privateKey bool
+	entropy    float64
+}
+
+// New는 기본 규칙에 opts를 반영한 Redactor를 만든다.
+func New(opts Options) (*Redactor, error) {
+	disabled := map[string]bool{}
+	for _, name := range opts.Disable {
+		disabled[strings.TrimSpace(name)] = true
+	}
+
+	r := &Redactor{
+		privateKey: !disabled[RulePrivateKey],
+		entropy:    opts.EntropyThreshold,
+	}
+	if r.entropy == 0 {
+		r.entropy = DefaultEntropyThreshold
+	}
+	if disabled[RuleHighEntropy] {
+		r.entropy = -1
+	}
+
+	for _, rule := range DefaultRules {
+		if !disabled[rule.Name] {
+			r.rules = append(r.rules, rule)
+		}
+	}
+
+	names := make([]string, 0, len(opts.Patterns))
+	for name := range opts.Patterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		re, err := regexp.Compile(opts.Patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("redact: compile pattern %q: %w", name, err)
+		}
+		r.rules = append(r.rules, Rule{Name: name, Pattern: re})
+	}
+
+	return r, nil
+}
+
+// Patch는 헌크 본문의 민감 정보를 "[REDACTED:규칙]"으로 바꾼 새 Patch와 요약을 반환한다.
+// 파일 헤더(경로, 모드)는 그대로 둔다.
+func (r *Redactor) Patch(p *gittool.Patch) (*gittool.Patch, Report) {
+	var report Report
+	if p.Empty() {
+		return p, report
+	}
+
+	out := &gittool.Patch{Files: make([]gittool.FileDiff, 0, len(p.Files))}
+	for _, f := range p.Files {
+		path := f.Path()
+		hunks := make([]gittool.Hunk, 0, len(f.Hunks))
+		inKey := false
+		for _, h := range f.Hunks {
+			// 헌크 머리의 함수 문맥은 본문 줄과 별개이므로 개인 키 블록 상태를 이어 받지 않는다.
+			if ranges, context := splitHunkHeader(h.Header); context != "" {
+				var names []string
+				context, _, names = r.line(context, false)
+				for _, name := range names {
+					report.Findings = append(report.Findings, Finding{Rule: name, Path: path})
+				}
+				h.Header = ranges + context
+			}
+			lines := make([]string, len(h.Lines))
+			for i, line := range h.Lines {
+				prefix, body := splitPrefix(line)
+				var names []string
+				body, inKey, names = r.line(body, inKey)
+				for _, name := range names {
+					report.Findings = append(report.Findings, Finding{Rule: name, Path: path})
+				}
+				lines[i] = prefix + body
+			}
+			h.Lines = lines
+			hunks = append(hunks, h)
+		}
+		f.Hunks = hunks
+		out.Files = append(out.Files, f)
+	}
+
+	return out, report
+}
+
+// Text는 커밋 메시지처럼 diff 접두사가 없는 여러 줄 텍스트를 가린다. path는 요약에 표시할 출처다.
+func (r *Redactor) Text(path, text string) (string, Report) {
+	var report Report
+	lines := strings.Split(text, "\n")
+	inKey := false
+	for i, line := range lines {
+		var names []string
+		lines[i], inKey, names = r.line(line, inKey)
+		for _, name := range names {
+			report.Findings = append(report.Findings, Finding{Rule: name, Path: path})
+		}
+	}
+	return strings.Join(lines, "\n"), report
+}
+
+// line은 한 줄을 가리고, 개인 키 블록 안에 있는지 여부와 적용된 규칙 이름을 반환한다.
+func (r *Redactor) line(body string, inKey bool) (string, bool, []string) {
+	if r.privateKey {
+		if inKey {
+			if privateKeyEnd.MatchString(body) {
+				return mask(RulePrivateKey), false, nil
+			}
+			return mask(RulePrivateKey), true, nil
+		}
+		if privateKeyBegin.MatchString(body) {
+			return mask(RulePrivateKey), !privateKeyEnd.MatchString(body), []string{RulePrivateKey}
+		}
+	}
+
+	var names []string
+	for _, rule := range r.rules {
+		var n int
+		body, n = replaceRule(rule, body)
+		for i := 0; i < n; i++ {
+			names = append(names, rule.Name)
+		}
+	}
+
+	if r.entropy > 0 {
+		body = entropyToken.ReplaceAllStringFunc(body, func(tok string) string {
+			if strings.Contains(tok, "REDACTED") || !hasDigit.MatchString(tok) || !hasLetter.MatchString(tok) {
+				return tok
+			}
+			if shannonEntropy(tok) < r.entropy {
+				return tok
+			}
+			names = append(names, RuleHighEntropy)
+			return mask(RuleHighEntropy)
+		})
+	}
+
+	return body, false, names
+}
+
+// replaceRule은 rule과 일치하는 부분(또는 secret 그룹)을 가리고 가린 개수를 반환한다.
+func replaceRule(rule Rule, s string) (string, int) {
+	group := rule.Pattern.SubexpIndex("secret")
+	matches := rule.Pattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, 0
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if group > 0 && m[2*group] >= 0 {
+			start, end = m[2*group], m[2*group+1]
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(mask(rule.Name))
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String(), len(matches)
+}
+
+func mask(rule string) string {
+	return "[REDACTED:" + rule + "]"
+}
+
+// splitPrefix는 헌크 줄의 "+", "-", " " 접두사를 분리한다.
+func splitPrefix(line string) (string, string) {
+	if line == "" {
+		return "", ""
+	}
+	switch line[0] {
+	case '+', '-', ' ':
+		return line[:1], line[1:]
+	}
+	return "", line
+}
+
+// splitHunkHeader는 "@@ -a,b +c,d @@ 문맥" 줄을 두 번째 "@@"까지와 그 뒤의 함수 문맥으로 나눈다.
+func splitHunkHeader(header string) (string, string) {
+	if !strings.HasPrefix(header, "@@") {
+		return header, ""
+	}
+	i := strings.Index(header[2:], "@@")
+	if i < 0 {
+		return header, ""
+	}
+	end := i + 4
+	return header[:end], header[end:]
+}
+
+// shannonEntropy는 문자당 Shannon 엔트로피(비트)를 계산한다.
+func shannonEntropy(s string) float64 {
+	freq := map[rune]float64{}
+	for _, r := range s {
+		freq[r]++
+	}
+	n := float64(len([]rune(s)))
+	var h float64
+	for _, c := range freq {
+		p := c / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
@@ -0,0 +1,605 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledzpl/pcl/internal/adf"
+	aitool "github.com/ledzpl/pcl/internal/ai"
+	"github.com/ledzpl/pcl/internal/config"
+	gittool "github.com/ledzpl/pcl/internal/git"
+	jira "github.com/ledzpl/pcl/internal/jira"
+
+	"github.com/manifoldco/promptui"
+)
+
+const (
+	issueReviewAccept     = "이 내용으로 생성"
+	issueReviewSummary    = "제목 수정"
+	issueReviewType       = "이슈 타입 변경"
+	issueReviewParent     = "상위 이슈(에픽) 지정"
+	issueReviewLinks      = "연결할 이슈 지정"
+	issueReviewEditor     = "$EDITOR로 전체 페이로드 수정"
+	issueReviewRegenerate = "다시 생성"
+	issueReviewCancel     = "취소"
+)
+
+// issueFlow는 이슈 생성 과정의 선택 사항이다.
+type issueFlow struct {
+	// dryRun이 true면 페이로드만 출력하고 이슈는 만들지 않는다.
+	dryRun bool
+	// force가 true면 사소한 변경이어도 이슈를 만든다.
+	force bool
+	// interactive가 true면 모델이 이슈를 만들지 않기로 했을 때 그래도 만들지 묻는다.
+	interactive bool
+	// review가 true면 생성 전에 미리 보기를 보여주고 승인·수정·재생성·취소를 고르게 한다.
+	review bool
+	// json이 true면 결과를 스크립트에서 읽기 쉬운 JSON 한 줄로 출력한다.
+	json bool
+	// parent는 상위 이슈(에픽 또는 부모) 키다. 비어 있으면 지정하지 않고, auto면 브랜치·커밋에서 찾은 키를 쓴다.
+	parent string
+	// links는 이슈를 만든 뒤 연결할 기존 이슈다.
+	links []jira.Link
+	// base는 이슈 키를 찾을 커밋 범위의 기준 브랜치다. 비어 있으면 브랜치 이름만 본다.
+	base string
+	// fields는 명령행에서 지정한 라벨·컴포넌트·우선순위·수정 버전·스프린트다.
+	fields issueFieldOptions
+	// split이 true면 diff를 변경 의도별로 나눠 이슈 여러 개를 만든다.
+	split bool
+	// epic이 true면 split으로 만든 이슈들을 에픽 하나로 묶는다.
+	epic bool
+}
+
+// issueFieldOptions는 새 이슈에 붙일 라벨, 컴포넌트, 우선순위, 수정 버전과 스프린트 여부다.
+// 설정 파일의 jira_labels 등과 합쳐 적용한다.
+type issueFieldOptions struct {
+	labels      []string
+	components  []string
+	fixVersions []string
+	priority    string
+	sprint      bool
+}
+
+// applyFieldDefaults는 모델이 제안한 라벨·컴포넌트에 설정과 플래그 값을 더한다.
+// 우선순위는 플래그가 있으면 플래그, 없으면 모델 값, 그것도 없으면 설정 값이다.
+func applyFieldDefaults(f *jira.IssueFields, cfg *config.Config, opts issueFieldOptions) {
+	f.Labels = appendMissing(f.Labels, slices.Concat(cfg.JiraLabels, opts.labels)...)
+
+	names := appendMissing(jira.Names(f.Components), slices.Concat(cfg.JiraComponents, opts.components)...)
+	f.Components = namedRefs(names)
+
+	versions := appendMissing(jira.Names(f.FixVersions), slices.Concat(cfg.JiraFixVersions, opts.fixVersions)...)
+	f.FixVersions = namedRefs(versions)
+
+	switch {
+	case opts.priority != "":
+		f.Priority = &jira.NamedRef{Name: opts.priority}
+	case f.Priority == nil && !IsBlank(cfg.JiraPriority):
+		f.Priority = &jira.NamedRef{Name: strings.TrimSpace(cfg.JiraPriority)}
+	}
+}
+
+// appendMissing은 list에 없는 values만 순서대로 덧붙인다. 빈 값은 건너뛴다.
+func appendMissing(list []string, values ...string) []string {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func namedRefs(names []string) []jira.NamedRef {
+	if len(names) == 0 {
+		return nil
+	}
+	refs := make([]jira.NamedRef, len(names))
+	for i, n := range names {
+		refs[i] = jira.NamedRef{Name: n}
+	}
+	return refs
+}
+
+// issueOutput은 -json으로 출력하는 이슈 생성 결과다.
+type issueOutput struct {
+	ID      string   `json:"id,omitempty"`
+	Key     string   `json:"key,omitempty"`
+	Self    string   `json:"self,omitempty"`
+	URL     string   `json:"url,omitempty"`
+	Links   []string `json:"links,omitempty"`
+	Sprint  string   `json:"sprint,omitempty"`
+	Skipped bool     `json:"skipped,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// issueGenerator는 한 번 요약한 diff로 이슈 페이로드를 여러 번 생성할 수 있게 한다.
+type issueGenerator struct {
+	cfg      *config.Config
+	jira     *jira.Client
+	patch    *gittool.Patch
+	provider aitool.Provider
+	diff     string
+	user     *jira.User
+	// meta는 프로젝트의 이슈 타입과 필드 정보다. 조회에 실패하면 nil이고 기본 검증만 한다.
+	meta *jira.ProjectMeta
+	// parent가 있으면 생성한 페이로드의 상위 이슈로 지정한다.
+	parent string
+	// detected는 브랜치·커밋에서 찾은 이슈 키다. 상위 이슈와 연결 대상의 기본값으로 제안한다.
+	detected string
+	// labels는 이미 쓰고 있는 라벨이다. 모델은 이 중에서만 라벨을 제안한다.
+	labels []string
+	// fields는 생성한 페이로드에 더할 라벨·컴포넌트·우선순위·수정 버전이다.
+	fields issueFieldOptions
+}
+
+// newIssueGenerator는 설정을 검사하고 Jira 사용자 조회와 diff 요약을 미리 해 둔다.
+func newIssueGenerator(cfg *config.Config, patch *gittool.Patch) (*issueGenerator, error) {
+	if err := cfg.ValidateForJira(); err != nil {
+		return nil, fmt.Errorf("설정이 올바르지 않습니다: %w", err)
+	}
+
+	s := startSpinner("diff 분석 준비 중... ", "")
+	s.FinalMSG = ""
+	defer stopSpinner(s)
+
+	client := newJiraClient(cfg)
+	user, err := client.CurrentUser(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Jira user: %w", err)
+	}
+	meta, err := client.ProjectMeta(context.Background(), cfg.JiraProject)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "프로젝트 메타데이터를 가져오지 못해 기본 이슈 타입(%s)으로 진행합니다: %v\n", strings.Join(jira.IssueTypes, ", "), err)
+	}
+	labels, err := client.Labels(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "라벨 목록을 가져오지 못해 라벨을 제안하지 않습니다: %v\n", err)
+	}
+
+	provider, err := newProvider(cfg, config.ActionIssue)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := aitool.Condense(context.Background(), provider, patch, chunkOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize diff: %w", err)
+	}
+
+	return &issueGenerator{cfg: cfg, jira: client, patch: patch, provider: provider, diff: diff, user: user, meta: meta, labels: labels}, nil
+}
+
+// generate는 이슈 페이로드를 생성한다. 모델이 이슈를 만들지 않기로 하면 *aitool.SkipError를 반환한다.
+func (g *issueGenerator) generate(force bool) (*jira.IssuePayload, error) {
+	s := startSpinner("Jira 이슈 내용 생성 중... ", "")
+	s.FinalMSG = ""
+
+	result, err := aitool.GenerateIssue(context.Background(), g.provider, g.diff, aitool.IssueOptions{
+		AccountID:  g.user.ID(),
+		Project:    g.cfg.JiraProject,
+		MaxRepairs: maxRepairs(g.cfg),
+		Force:      force,
+		Patch:      g.patch,
+		Meta:       g.meta,
+		Labels:     g.labels,
+	})
+	stopSpinner(s)
+
+	var skip *aitool.SkipError
+	if errors.As(err, &skip) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze diff: %w", err)
+	}
+	if result.Fallback {
+		fmt.Fprintf(os.Stderr, "AI 응답을 %d번 요청해도 검증을 통과하지 못해 변경 파일 목록으로 기본 이슈를 만들었습니다: %v\n", result.Attempts, result.LastError)
+	}
+	g.finish(result.Payload)
+	return result.Payload, nil
+}
+
+// finish는 모델이 만든 페이로드에 담당자, 상위 이슈, 설정·플래그의 필드 값을 채운다.
+func (g *issueGenerator) finish(payload *jira.IssuePayload) {
+	// 담당자는 모델이 옮겨 적은 값 대신 조회한 사용자로 채운다 (Data Center는 accountId가 아니라 name).
+	payload.Fields.Assignee = g.user.Ref()
+	payload.Fields.Parent = nil
+	if g.parent != "" {
+		payload.Fields.Parent = &jira.IssueRef{Key: g.parent}
+	}
+	applyFieldDefaults(&payload.Fields, g.cfg, g.fields)
+}
+
+// issueTypes는 리뷰에서 고를 수 있는 이슈 타입이다. 메타데이터가 없으면 Story/Task다.
+func (g *issueGenerator) issueTypes() []string {
+	if g.meta == nil {
+		return jira.IssueTypes
+	}
+	return g.meta.TypeNames()
+}
+
+// createIssue는 patch로 Jira 이슈 페이로드를 생성하고, dryRun이 아니면 Jira에 등록한다.
+func createIssue(cfg *config.Config, patch *gittool.Patch, flow issueFlow) error {
+	g, err := newIssueGenerator(cfg, patch)
+	if err != nil {
+		return err
+	}
+	g.fields = flow.fields
+	g.detected = detectIssueKey(cfg, flow.base)
+	g.parent, err = resolveParent(flow.parent, g.detected)
+	if err != nil {
+		return err
+	}
+	links := flow.links
+	if g.detected != "" && g.detected != g.parent && !slices.ContainsFunc(links, func(l jira.Link) bool { return l.Key == g.detected }) {
+		if !warnExistingIssue(g.jira, g.detected, flow.interactive) {
+			return nil
+		}
+	}
+	if flow.split {
+		return createSplitIssues(g, flow, links)
+	}
+
+	payload, err := g.generate(flow.force)
+	var skip *aitool.SkipError
+	if errors.As(err, &skip) {
+		if flow.json && !flow.interactive {
+			return printJSON(issueOutput{Skipped: true, Reason: skip.Reason})
+		}
+		explainSkip(skip)
+		if !flow.interactive {
+			fmt.Println("그래도 이슈를 만들려면 -force를 지정하세요.")
+			return nil
+		}
+		if !confirm("그래도 이슈를 만들까요") {
+			return nil
+		}
+		payload, err = g.generate(true)
+	}
+	if err != nil {
+		return err
+	}
+
+	if flow.review {
+		payload, links, err = reviewIssue(g, payload, links)
+		if err != nil {
+			return err
+		}
+	}
+
+	if flow.dryRun {
+		return printPayload(payload)
+	}
+
+	s := startSpinner("Jira 이슈 생성 중... ", "Jira 이슈 생성 완료\n")
+	if flow.json {
+		s.FinalMSG = ""
+	}
+	created, err := g.jira.CreateIssue(context.Background(), payload)
+	if err != nil {
+		s.FinalMSG = ""
+		stopSpinner(s)
+		return fmt.Errorf("failed to create Jira issue: %w", err)
+	}
+	stopSpinner(s)
+
+	linked := linkIssue(g.jira, created.Key, links)
+	var sprint string
+	if flow.fields.sprint || cfg.JiraActiveSprint {
+		sprint = addToActiveSprint(cfg, g.jira, created.Key)
+	}
+	applyConfiguredTransition(cfg, g.jira, config.ActionIssue, created.Key)
+
+	url := jira.BrowseURL(g.jira.Host(), created.Key)
+	if flow.json {
+		return printJSON(issueOutput{ID: created.ID, Key: created.Key, Self: created.Self, URL: url, Links: linked, Sprint: sprint})
+	}
+	fmt.Printf("%s %s\n", created.Key, url)
+	for _, l := range linked {
+		fmt.Printf("  연결: %s\n", l)
+	}
+	if sprint != "" {
+		fmt.Printf("  스프린트: %s\n", sprint)
+	}
+	return nil
+}
+
+// addToActiveSprint는 keys 이슈를 진행 중인 스프린트에 넣고 스프린트 이름을 반환한다.
+// 이슈는 이미 만들어졌으므로 실패해도 중단하지 않고 표준 에러로 알린 뒤 빈 문자열을 반환한다.
+func addToActiveSprint(cfg *config.Config, client *jira.Client, keys ...string) string {
+	ctx := context.Background()
+	joined := strings.Join(keys, ", ")
+	sprint, err := client.ActiveSprint(ctx, cfg.JiraProject, cfg.JiraBoardID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "진행 중인 스프린트를 찾지 못해 %s 이슈를 스프린트에 넣지 않았습니다: %v\n", joined, err)
+		return ""
+	}
+	if err := client.AddToSprint(ctx, sprint.ID, keys...); err != nil {
+		fmt.Fprintf(os.Stderr, "%s 이슈를 %s 스프린트에 넣지 못했습니다: %v\n", joined, sprint.Name, err)
+		return ""
+	}
+	return sprint.Name
+}
+
+// linkIssue는 key 이슈를 links의 이슈와 연결하고, 연결에 성공한 링크를 반환한다.
+// 이슈는 이미 만들어졌으므로 연결 실패는 경고만 출력한다.
+func linkIssue(client *jira.Client, key string, links []jira.Link) []string {
+	var linked []string
+	for _, l := range links {
+		if err := client.LinkIssue(context.Background(), key, l); err != nil {
+			fmt.Fprintf(os.Stderr, "%s 이슈를 %s(으)로 연결하지 못했습니다: %v\n", key, l, err)
+			continue
+		}
+		linked = append(linked, l.String())
+	}
+	return linked
+}
+
+// reviewIssue는 사용자가 승인할 때까지 미리 보기와 수정·재생성을 반복한다.
+// 승인한 페이로드와 이슈 생성 후 연결할 링크를 반환한다.
+func reviewIssue(g *issueGenerator, payload *jira.IssuePayload, links []jira.Link) (*jira.IssuePayload, []jira.Link, error) {
+	for {
+		fmt.Printf("\n%s\n", renderIssuePreview(payload, links))
+
+		p := promptui.Select{
+			Label: "생성될 Jira 이슈",
+			Items: []string{issueReviewAccept, issueReviewSummary, issueReviewType, issueReviewParent, issueReviewLinks, issueReviewEditor, issueReviewRegenerate, issueReviewCancel},
+		}
+		_, choice, err := p.Run()
+		if err != nil {
+			return nil, nil, errCanceled
+		}
+
+		switch choice {
+		case issueReviewAccept:
+			return payload, links, nil
+		case issueReviewSummary:
+			summary, err := promptSummary(payload.Fields.Summary)
+			if err != nil {
+				continue
+			}
+			payload.Fields.Summary = summary
+		case issueReviewType:
+			t := promptui.Select{Label: "이슈 타입", Items: g.issueTypes()}
+			_, name, err := t.Run()
+			if err != nil {
+				continue
+			}
+			payload.Fields.IssueType.Name = name
+		case issueReviewParent:
+			parent, err := promptParent(cmp.Or(g.parent, g.detected))
+			if err != nil {
+				continue
+			}
+			g.parent = parent
+			payload.Fields.Parent = nil
+			if parent != "" {
+				payload.Fields.Parent = &jira.IssueRef{Key: parent}
+			}
+		case issueReviewLinks:
+			edited, err := promptLinks(links, g.detected)
+			if err != nil {
+				continue
+			}
+			links = edited
+		case issueReviewEditor:
+			edited, err := editPayload(payload, g.meta)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "수정한 페이로드를 사용할 수 없습니다: %v\n", err)
+				continue
+			}
+			payload = edited
+			g.parent = ""
+			if edited.Fields.Parent != nil {
+				g.parent = edited.Fields.Parent.Key
+			}
+		case issueReviewRegenerate:
+			regenerated, err := g.generate(true)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "다시 생성하지 못했습니다: %v\n", err)
+				continue
+			}
+			payload = regenerated
+		default:
+			return nil, nil, errCanceled
+		}
+	}
+}
+
+// renderIssuePreview는 페이로드의 제목, 타입, 담당자, 상위 이슈, 연결할 이슈, 설명을 읽기 좋은 텍스트로 만든다.
+func renderIssuePreview(payload *jira.IssuePayload, links []jira.Link) string {
+	f := payload.Fields
+	assignee := "(없음)"
+	if f.Assignee != nil && f.Assignee.ID() != "" {
+		assignee = f.Assignee.ID()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "프로젝트: %s\n", f.Project.Key)
+	fmt.Fprintf(&b, "제목: %s\n", f.Summary)
+	fmt.Fprintf(&b, "타입: %s\n", f.IssueType.Name)
+	fmt.Fprintf(&b, "담당자: %s\n", assignee)
+	if f.Parent != nil {
+		fmt.Fprintf(&b, "상위 이슈: %s\n", f.Parent.Key)
+	}
+	if f.Priority != nil {
+		fmt.Fprintf(&b, "우선순위: %s\n", f.Priority.Name)
+	}
+	if len(f.Labels) > 0 {
+		fmt.Fprintf(&b, "라벨: %s\n", strings.Join(f.Labels, ", "))
+	}
+	if len(f.Components) > 0 {
+		fmt.Fprintf(&b, "컴포넌트: %s\n", strings.Join(jira.Names(f.Components), ", "))
+	}
+	if len(f.FixVersions) > 0 {
+		fmt.Fprintf(&b, "수정 버전: %s\n", strings.Join(jira.Names(f.FixVersions), ", "))
+	}
+	if len(links) > 0 {
+		names := make([]string, len(links))
+		for i, l := range links {
+			names[i] = l.String()
+		}
+		fmt.Fprintf(&b, "연결: %s\n", strings.Join(names, ", "))
+	}
+	b.WriteString("설명:\n")
+	for _, line := range strings.Split(strings.TrimRight(adf.Render(f.Description), "\n"), "\n") {
+		if line == "" {
+			b.WriteByte('\n')
+			continue
+		}
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+	return b.String()
+}
+
+func promptSummary(current string) (string, error) {
+	p := promptui.Prompt{
+		Label:   "제목",
+		Default: current,
+		Validate: func(s string) error {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				return errors.New("제목을 입력하세요")
+			}
+			if n := utf8.RuneCountInString(s); n > jira.MaxSummaryLength {
+				return fmt.Errorf("제목은 %d자 이내여야 합니다 (현재 %d자)", jira.MaxSummaryLength, n)
+			}
+			return nil
+		},
+	}
+	summary, err := p.Run()
+	return strings.TrimSpace(summary), err
+}
+
+// promptParent는 상위 이슈 키를 묻는다. 비워 두면 상위 이슈를 지정하지 않는다.
+func promptParent(current string) (string, error) {
+	return promptIssueKey("상위 이슈 키 (예: PCL-10, 비우면 지정 안 함)", current, true)
+}
+
+// promptIssueKey는 이슈 키를 묻는다. optional이면 빈 값도 받는다.
+func promptIssueKey(label, current string, optional bool) (string, error) {
+	p := promptui.Prompt{
+		Label:   label,
+		Default: current,
+		Validate: func(s string) error {
+			s = strings.ToUpper(strings.TrimSpace(s))
+			if (s != "" || !optional) && !jira.ValidIssueKey(s) {
+				return errors.New("PCL-123 형태의 이슈 키를 입력하세요")
+			}
+			return nil
+		},
+	}
+	key, err := p.Run()
+	return strings.ToUpper(strings.TrimSpace(key)), err
+}
+
+// promptLinks는 연결할 이슈를 "관계:키"를 쉼표로 구분한 목록으로 묻는다.
+// 아직 링크가 없으면 브랜치·커밋에서 찾은 detected를 relates로 제안한다.
+func promptLinks(current []jira.Link, detected string) ([]jira.Link, error) {
+	names := make([]string, len(current))
+	for i, l := range current {
+		names[i] = l.String()
+	}
+	if len(names) == 0 && detected != "" {
+		names = append(names, jira.Link{Type: "Relates", Key: detected}.String())
+	}
+	p := promptui.Prompt{
+		Label:   fmt.Sprintf("연결할 이슈 (예: blocks:PCL-12, relates:PCL-3 / 관계: %s)", strings.Join(jira.LinkNames(), ", ")),
+		Default: strings.Join(names, ", "),
+		Validate: func(s string) error {
+			_, err := parseLinks(s)
+			return err
+		},
+	}
+	value, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+	return parseLinks(value)
+}
+
+// parseLinks는 쉼표로 구분한 "관계:키" 목록을 읽는다.
+func parseLinks(value string) ([]jira.Link, error) {
+	var links []jira.Link
+	for _, part := range strings.Split(value, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		l, err := jira.ParseLink(part)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+	return links, nil
+}
+
+// editPayload는 페이로드 JSON 전체를 $EDITOR로 열고, 저장된 내용을 meta 기준으로 파싱·검증해 돌려준다.
+func editPayload(payload *jira.IssuePayload, meta *jira.ProjectMeta) (*jira.IssuePayload, error) {
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	edited, err := editText(string(data), "pcl-issue-*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := jira.ParseIssuePayload(edited)
+	if err != nil {
+		return nil, err
+	}
+	if err := parsed.ValidateFor(meta); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// explainSkip은 모델이 이슈를 만들지 않은 이유를 출력한다.
+func explainSkip(skip *aitool.SkipError) {
+	fmt.Println("사소한 변경으로 판단되어 이슈를 만들지 않았습니다.")
+	if skip.Reason != "" {
+		fmt.Printf("이유: %s\n", skip.Reason)
+	}
+}
+
+// confirm은 예/아니오를 묻는다. 취소(Ctrl+C 등)는 아니오로 본다.
+func confirm(label string) bool {
+	p := promptui.Prompt{Label: label, IsConfirm: true}
+	_, err := p.Run()
+	return err == nil
+}
+
+func printPayload(payload *jira.IssuePayload) error {
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func printJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// maxRepairs는 AI 응답 검증 실패 시 다시 요청할 횟수다.
+func maxRepairs(cfg *config.Config) int {
+	if cfg.AIMaxRepairs == nil {
+		return aitool.DefaultMaxRepairs
+	}
+	return *cfg.AIMaxRepairs
+}
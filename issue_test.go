@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ledzpl/pcl/internal/adf"
+	"github.com/ledzpl/pcl/internal/config"
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+func TestRenderIssuePreview(t *testing.T) {
+	payload := &jira.IssuePayload{Fields: jira.IssueFields{
+		Project:     jira.ProjectRef{Key: "PCL"},
+		Summary:     "diff 필터 추가",
+		IssueType:   jira.IssueTypeRef{Name: "Task"},
+		Description: adf.Doc(adf.Heading(3, "배경"), adf.BulletList("잠금 파일 제외")),
+	}}
+
+	want := `프로젝트: PCL
+제목: diff 필터 추가
+타입: Task
+담당자: (없음)
+설명:
+  ### 배경
+
+  - 잠금 파일 제외
+`
+	if got := renderIssuePreview(payload, nil); got != want {
+		t.Fatalf("renderIssuePreview() =\n%s\nwant\n%s", got, want)
+	}
+
+	payload.Fields.Assignee = &jira.UserRef{AccountID: "abc-123"}
+	if got := renderIssuePreview(payload, nil); !strings.Contains(got, "담당자: abc-123\n") {
+		t.Fatalf("renderIssuePreview() missing assignee:\n%s", got)
+	}
+
+	payload.Fields.Parent = &jira.IssueRef{Key: "PCL-10"}
+	links := []jira.Link{{Type: "Blocks", Key: "PCL-12"}, {Type: "Relates", Key: "PCL-3"}}
+	got := renderIssuePreview(payload, links)
+	if !strings.Contains(got, "상위 이슈: PCL-10\n") || !strings.Contains(got, "연결: blocks:PCL-12, relates:PCL-3\n") {
+		t.Fatalf("renderIssuePreview() missing parent or links:\n%s", got)
+	}
+
+	payload.Fields.Labels = []string{"backend", "pcl"}
+	payload.Fields.Priority = &jira.NamedRef{Name: "High"}
+	got = renderIssuePreview(payload, nil)
+	if !strings.Contains(got, "우선순위: High\n라벨: backend, pcl\n") {
+		t.Fatalf("renderIssuePreview() missing priority or labels:\n%s", got)
+	}
+}
+
+func TestParseLinks(t *testing.T) {
+	links, err := parseLinks(" blocks:PCL-12, ,is-blocked-by:pcl-3 ")
+	if err != nil {
+		t.Fatalf("parseLinks() error: %v", err)
+	}
+	want := []jira.Link{{Type: "Blocks", Key: "PCL-12"}, {Type: "Blocks", Key: "PCL-3", Inward: true}}
+	if len(links) != 2 || links[0] != want[0] || links[1] != want[1] {
+		t.Fatalf("parseLinks() = %+v, want %+v", links, want)
+	}
+
+	if _, err := parseLinks("blocks:PCL-12,causes:PCL-1"); err == nil {
+		t.Fatal("parseLinks() expected error for unknown link type")
+	}
+}
+
+func TestApplyFieldDefaults(t *testing.T) {
+	cfg := &config.Config{
+		JiraLabels:      []string{"pcl", "backend"},
+		JiraComponents:  []string{"cli"},
+		JiraFixVersions: []string{"1.2.0"},
+		JiraPriority:    "Medium",
+	}
+
+	f := jira.IssueFields{Labels: []string{"backend"}, Components: []jira.NamedRef{{Name: "jira"}}}
+	applyFieldDefaults(&f, cfg, issueFieldOptions{labels: []string{"urgent"}, components: []string{"cli"}})
+	if got := strings.Join(f.Labels, ","); got != "backend,pcl,urgent" {
+		t.Fatalf("labels = %s", got)
+	}
+	if got := strings.Join(jira.Names(f.Components), ","); got != "jira,cli" {
+		t.Fatalf("components = %s", got)
+	}
+	if got := strings.Join(jira.Names(f.FixVersions), ","); got != "1.2.0" {
+		t.Fatalf("fixVersions = %s", got)
+	}
+	if f.Priority == nil || f.Priority.Name != "Medium" {
+		t.Fatalf("priority = %+v, want config default Medium", f.Priority)
+	}
+
+	f = jira.IssueFields{Priority: &jira.NamedRef{Name: "High"}}
+	applyFieldDefaults(&f, cfg, issueFieldOptions{})
+	if f.Priority.Name != "High" {
+		t.Fatalf("priority = %s, want model value High kept over config", f.Priority.Name)
+	}
+	applyFieldDefaults(&f, cfg, issueFieldOptions{priority: "Low"})
+	if f.Priority.Name != "Low" {
+		t.Fatalf("priority = %s, want flag value Low", f.Priority.Name)
+	}
+
+	empty := jira.IssueFields{}
+	applyFieldDefaults(&empty, &config.Config{}, issueFieldOptions{})
+	if empty.Labels != nil || empty.Components != nil || empty.FixVersions != nil || empty.Priority != nil {
+		t.Fatalf("applyFieldDefaults() without defaults changed fields: %+v", empty)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ledzpl/pcl/internal/config"
+	gittool "github.com/ledzpl/pcl/internal/git"
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+// parentAuto는 -parent에 지정하면 브랜치·커밋에서 찾은 이슈 키를 상위 이슈로 쓰는 값이다.
+const parentAuto = "auto"
+
+// detectIssueKey는 현재 브랜치 이름과 base 이후 커밋 메시지에서 이슈 키를 찾는다.
+// jira_project가 있으면 그 프로젝트의 키만, 없으면 브랜치 이름의 키만 보고, 찾지 못하면 빈 문자열을 반환한다.
+func detectIssueKey(cfg *config.Config, base string) string {
+	keys, err := gittool.DetectIssueKeys(base, cfg.JiraProject)
+	if err != nil || len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
+
+// applyIssueKey는 mode에 따라 커밋 메시지 제목 앞(prefix)이나 끝의 Refs 트레일러(footer)에 key를 넣는다.
+// key가 없거나 메시지에 이미 key가 있으면 그대로 반환한다. PCL-12가 있다고 PCL-1이 있는 것으로 보지 않는다.
+func applyIssueKey(message, key, mode string) string {
+	if key == "" {
+		return message
+	}
+	project := key[:max(strings.LastIndex(key, "-"), 0)]
+	if slices.Contains(gittool.ExtractIssueKeys(message, project), key) {
+		return message
+	}
+	switch mode {
+	case config.IssueKeyPrefix:
+		return key + " " + message
+	case config.IssueKeyFooter:
+		return strings.TrimRight(message, "\n") + "\n\nRefs: " + key
+	}
+	return message
+}
+
+// resolveParent는 -parent 값을 상위 이슈 키로 바꾼다. auto면 detected를 쓴다.
+func resolveParent(parent, detected string) (string, error) {
+	if parent != parentAuto {
+		return parent, nil
+	}
+	if detected == "" {
+		return "", fmt.Errorf("-parent auto: 브랜치 이름이나 커밋 메시지에서 이슈 키를 찾지 못했습니다")
+	}
+	return detected, nil
+}
+
+// warnExistingIssue는 브랜치·커밋에서 찾은 key 이슈가 이미 있으면 중복 생성일 수 있다고 알린다.
+// interactive면 그래도 만들지 묻고, 아니라고 하면 false를 반환한다.
+func warnExistingIssue(client *jira.Client, key string, interactive bool) bool {
+	issue, err := client.GetIssue(context.Background(), key)
+	if err != nil {
+		if !jira.IsNotFound(err) {
+			fmt.Fprintf(os.Stderr, "%s 이슈를 확인하지 못했습니다: %v\n", key, err)
+		}
+		return true
+	}
+
+	fmt.Fprintf(os.Stderr, "브랜치/커밋에서 찾은 %s 이슈가 이미 있습니다: %s (%s, %s)\n", issue.Key, issue.Fields.Summary, issue.Fields.IssueType.Name, issue.Fields.Status.Name)
+	fmt.Fprintf(os.Stderr, "같은 작업이라면 새 이슈 대신 -parent %s 또는 -link relates:%s로 연결할 수 있습니다.\n", issue.Key, issue.Key)
+	if !interactive {
+		return true
+	}
+	return confirm("그래도 새 이슈를 만들까요")
+}
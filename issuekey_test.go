@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestApplyIssueKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		key     string
+		mode    string
+		want    string
+	}{
+		{"prefix", "feat: 재시도 추가", "PCL-123", "prefix", "PCL-123 feat: 재시도 추가"},
+		{"footer", "feat: 재시도 추가\n\n- 본문\n", "PCL-123", "footer", "feat: 재시도 추가\n\n- 본문\n\nRefs: PCL-123"},
+		{"none", "feat: 재시도 추가", "PCL-123", "none", "feat: 재시도 추가"},
+		{"noKey", "feat: 재시도 추가", "", "prefix", "feat: 재시도 추가"},
+		{"alreadyPresent", "feat: PCL-123 재시도 추가", "PCL-123", "footer", "feat: PCL-123 재시도 추가"},
+		{"longerKeyPresent", "feat: PCL-12 후속 작업", "PCL-1", "prefix", "PCL-1 feat: PCL-12 후속 작업"},
+		{"projectWithDigits", "feat: OPS_2-40 정리", "OPS_2-40", "footer", "feat: OPS_2-40 정리"},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			if got := applyIssueKey(caseData.message, caseData.key, caseData.mode); got != caseData.want {
+				t.Fatalf("applyIssueKey() = %q, want %q", got, caseData.want)
+			}
+		})
+	}
+}
+
+func TestResolveParent(t *testing.T) {
+	if got, err := resolveParent("PCL-10", "PCL-123"); err != nil || got != "PCL-10" {
+		t.Fatalf("resolveParent(explicit) = %q, %v", got, err)
+	}
+	if got, err := resolveParent(parentAuto, "PCL-123"); err != nil || got != "PCL-123" {
+		t.Fatalf("resolveParent(auto) = %q, %v", got, err)
+	}
+	if _, err := resolveParent(parentAuto, ""); err == nil {
+		t.Fatal("resolveParent(auto) expected error when no key was detected")
+	}
+}
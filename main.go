@@ -1,9 +1,11 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/ledzpl/pcl/internal/config"
 	gittool "github.com/ledzpl/pcl/internal/git"
 	jira "github.com/ledzpl/pcl/internal/jira"
+	"github.com/ledzpl/pcl/internal/redact"
 
 	"github.com/briandowns/spinner"
 	"github.com/manifoldco/promptui"
@@ -18,7 +21,10 @@ import (
 
 const (
 	actionCreateJiraIssue  = "Jira 이슈 생성"
+	actionSplitJiraIssue   = "Jira 이슈 여러 개로 나눠 생성"
+	actionUpdateJiraIssue  = "Jira 이슈 갱신"
 	actionCommitMessage    = "커밋 메시지 생성"
+	actionCreateCommit     = "커밋 메시지 생성 후 커밋"
 	defaultSpinnerFinalMsg = "완료\n"
 )
 
@@ -34,6 +40,14 @@ const pcl string = `
 
 `
 
+// sourceLabels는 gittool.Sources와 같은 순서로 대화형 메뉴에 표시할 이름이다.
+var sourceLabels = []string{
+	"기준 브랜치 대비 전체 작업 트리",
+	"스테이징된 변경만 (--cached)",
+	"스테이징되지 않은 변경만",
+	"기준 브랜치 대비 커밋된 변경만",
+}
+
 var rainbowColors = []string{
 	"\033[31m",
 	"\033[33m",
@@ -45,31 +59,56 @@ var rainbowColors = []string{
 
 func main() {
 	configPath := flag.String("config", "config.json", "path to configuration file")
+	flag.Usage = usage
 	flag.Parse()
 
+	if flag.NArg() > 0 {
+		if err := runCommand(flag.Args(), *configPath); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	runInteractive(*configPath)
+}
+
+func runInteractive(configPath string) {
 	printRainbowASCIIArt(pcl)
 
-	cfg, err := config.Load(*configPath)
+	cfg, err := config.Load(configPath)
 	if err != nil {
-		log.Fatalf("failed to load config from %s: %v", *configPath, err)
+		log.Fatalf("failed to load config from %s: %v", configPath, err)
 	}
 
-	branches := gittool.GetBranches()
-
-	p := promptui.Select{Label: "Select base branch", Items: branches}
-	_, result, err := p.Run()
+	sourcePrompt := promptui.Select{
+		Label: "diff 범위 선택",
+		Items: sourceLabels,
+	}
+	i, _, err := sourcePrompt.Run()
 	if err != nil {
 		return
 	}
+	source := gittool.Sources[i]
+
+	var base string
+	if source.NeedsBase() {
+		branches := gittool.GetBranches()
+
+		p := promptui.Select{Label: "Select base branch", Items: branches}
+		_, base, err = p.Run()
+		if err != nil {
+			return
+		}
+	}
 
-	diff := gittool.Diff(result)
-	if IsBlank(diff) {
-		log.Fatalf("비교할 변경점이 없습니다.")
+	patch, err := loadPatch(cfg, source, base)
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	actionPrompt := promptui.Select{
 		Label: "실행할 작업 선택",
-		Items: []string{actionCreateJiraIssue, actionCommitMessage},
+		Items: []string{actionCreateJiraIssue, actionSplitJiraIssue, actionUpdateJiraIssue, actionCommitMessage, actionCreateCommit},
 	}
 	_, action, err := actionPrompt.Run()
 	if err != nil {
@@ -78,41 +117,157 @@ func main() {
 
 	switch action {
 	case actionCreateJiraIssue:
-		if err := cfg.ValidateForJira(); err != nil {
-			log.Fatalf("설정이 올바르지 않습니다: %v", err)
+		if err := createIssue(cfg, patch, issueFlow{interactive: true, review: true, base: base}); err != nil {
+			log.Fatal(err)
 		}
-
-		s := startSpinner("Jira 이슈 생성 중... ", "Jira 이슈 생성 완료\n")
-
-		accountId, err := jira.GetAccountId(cfg.JiraEmail, cfg.JiraHost, cfg.JiraAPIKey)
+	case actionSplitJiraIssue:
+		flow := issueFlow{interactive: true, review: true, base: base, split: true, epic: confirm("에픽으로 묶을까요")}
+		if err := createIssue(cfg, patch, flow); err != nil {
+			log.Fatal(err)
+		}
+	case actionUpdateJiraIssue:
+		key, err := promptIssueKey("갱신할 이슈 키", detectIssueKey(cfg, base), false)
 		if err != nil {
-			s.FinalMSG = ""
-			stopSpinner(s)
-			log.Fatalf("failed to fetch Jira account ID: %v", err)
+			return
+		}
+		if err := updateIssue(cfg, patch, updateFlow{key: key, base: base, interactive: true}); err != nil {
+			log.Fatal(err)
+		}
+	case actionCommitMessage:
+		if err := generateCommitMessage(cfg, patch); err != nil {
+			log.Fatal(err)
+		}
+	case actionCreateCommit:
+		// 커밋되는 것은 인덱스뿐이므로 고른 범위와 상관없이 스테이징된 변경으로 메시지를 만든다.
+		if source != gittool.SourceStaged {
+			patch, err = loadPatch(cfg, gittool.SourceStaged, "")
+			if errors.Is(err, errNoChanges) {
+				err = errNothingStaged
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
 		}
+		if err := commitChanges(cfg, patch, false); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("지원하지 않는 작업입니다: %s", action)
+	}
+}
+
+// loadPatch는 source 범위의 diff를 읽고 설정된 제외 규칙을 적용한다.
+// 제외된 파일은 표준 에러로 알려 준다.
+func loadPatch(cfg *config.Config, source gittool.Source, base string) (*gittool.Patch, error) {
+	patch, err := gittool.LoadPatch(source, base)
+	if err != nil {
+		return nil, fmt.Errorf("diff를 가져올 수 없습니다: %w", err)
+	}
+	if patch.Empty() {
+		return nil, errNoChanges
+	}
+	return preparePatch(cfg, patch)
+}
 
-		airesponse := aitool.Analysis(diff, accountId, cfg.JiraProject, cfg.OpenAIAPIKey)
+// preparePatch는 patch에 설정된 제외 규칙과 민감 정보 가리기를 적용한다.
+func preparePatch(cfg *config.Config, patch *gittool.Patch) (*gittool.Patch, error) {
+	filter := gittool.Filter{
+		Include:    cfg.DiffInclude,
+		Exclude:    cfg.DiffExclude,
+		NoDefaults: cfg.DiffNoDefaultExcludes,
+	}
+	patch, excluded, err := filter.Apply(patch)
+	if err != nil {
+		return nil, err
+	}
 
-		if err := jira.CreateIssue(airesponse, cfg.JiraEmail, cfg.JiraHost, cfg.JiraAPIKey); err != nil {
-			s.FinalMSG = ""
-			stopSpinner(s)
-			log.Fatalf("failed to create Jira issue: %v", err)
+	if len(excluded) > 0 {
+		fmt.Fprintf(os.Stderr, "diff에서 제외한 파일 %d개:\n", len(excluded))
+		for _, e := range excluded {
+			fmt.Fprintf(os.Stderr, "  - %s (%s)\n", e.Path, e.Reason)
 		}
+	}
+	if patch.Empty() {
+		return nil, errors.New("제외 규칙을 적용하고 나니 남은 변경점이 없습니다")
+	}
 
-		stopSpinner(s)
-		fmt.Println(airesponse)
+	redactor, err := newRedactor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	patch, report := redactor.Patch(patch)
+	if err := checkRedaction(cfg, "diff", report); err != nil {
+		return nil, err
+	}
 
-	case actionCommitMessage:
-		if err := cfg.ValidateForAI(); err != nil {
-			log.Fatalf("설정이 올바르지 않습니다: %v", err)
+	return patch, nil
+}
+
+// newRedactor는 설정의 민감 정보 규칙으로 Redactor를 만든다.
+func newRedactor(cfg *config.Config) (*redact.Redactor, error) {
+	return redact.New(redact.Options{
+		Patterns:         cfg.RedactPatterns,
+		Disable:          cfg.RedactDisable,
+		EntropyThreshold: cfg.RedactEntropyThreshold,
+	})
+}
+
+// checkRedaction은 가린 값이 있으면 redact_block에 따라 중단하거나 표준 에러로 알린다. what은 가린 대상(diff 등)이다.
+func checkRedaction(cfg *config.Config, what string, report redact.Report) error {
+	if report.Total() == 0 {
+		return nil
+	}
+	if cfg.RedactBlock {
+		return fmt.Errorf("%s에 민감 정보가 있어 중단합니다: %s", what, report.Summary())
+	}
+	fmt.Fprintf(os.Stderr, "가린 %s\n", report.Summary())
+	return nil
+}
+
+// newJiraClient는 설정의 접속 정보와 타임아웃·재시도 횟수로 Jira 클라이언트를 만든다.
+func newJiraClient(cfg *config.Config) *jira.Client {
+	retries := jira.DefaultMaxRetries
+	if cfg.JiraMaxRetries != nil {
+		retries = *cfg.JiraMaxRetries
+	}
+	cacheDir, cacheTTL := jira.DefaultMetaCacheDir(), jira.DefaultMetaCacheTTL
+	if cfg.JiraMetaCacheHours != nil {
+		cacheTTL = time.Duration(*cfg.JiraMetaCacheHours) * time.Hour
+		if cacheTTL == 0 {
+			cacheDir = ""
 		}
+	}
+	return jira.NewClient(jira.Options{
+		Host:         cfg.JiraHost,
+		Deployment:   jira.Deployment(cfg.Deployment()),
+		Email:        cfg.JiraEmail,
+		Token:        cfg.JiraAPIKey,
+		Timeout:      time.Duration(cfg.JiraTimeoutSeconds) * time.Second,
+		MaxRetries:   retries,
+		MetaCacheDir: cacheDir,
+		MetaCacheTTL: cacheTTL,
+	})
+}
 
-		s := startSpinner("커밋 메시지 생성 중... ", "커밋 메시지가 준비되었습니다.\n")
-		message := aitool.CommitMessage(diff, cfg.OpenAIAPIKey)
-		stopSpinner(s)
-		fmt.Println(message)
-	default:
-		log.Fatalf("지원하지 않는 작업입니다: %s", action)
+// newProvider는 설정에 지정된 AI 프로바이더를 action에 맞는 모델로 만든다.
+func newProvider(cfg *config.Config, action string) (aitool.Provider, error) {
+	return aitool.NewProvider(aitool.ProviderConfig{
+		Name:            cfg.Provider(),
+		APIKey:          cfg.APIKey(),
+		BaseURL:         cfg.AIBaseURL,
+		Model:           cfg.ModelFor(action),
+		APIVersion:      cfg.AIAPIVersion,
+		Temperature:     cfg.AITemperature,
+		ReasoningEffort: cfg.AIReasoningEffort,
+		MaxTokens:       cfg.AIMaxTokens,
+		Seed:            cfg.AISeed,
+	})
+}
+
+func chunkOptions(cfg *config.Config) aitool.ChunkOptions {
+	return aitool.ChunkOptions{
+		ContextTokens: cfg.AIContextTokens,
+		Concurrency:   cfg.AIConcurrency,
 	}
 }
 
@@ -148,3 +303,15 @@ func printRainbowASCIIArt(s string) {
 	}
 	fmt.Println()
 }
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [-config path] [command] [flags]\n\n", os.Args[0])
+	fmt.Fprintln(out, "명령 없이 실행하면 대화형 모드로 동작합니다.")
+	fmt.Fprintln(out, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
+}
@@ -1,6 +1,10 @@
 package main
 
-import "testing"
+import (
+	"testing"
+
+	gittool "github.com/ledzpl/pcl/internal/git"
+)
 
 func TestIsBlank(t *testing.T) {
 	tests := []struct {
@@ -24,3 +28,9 @@ func TestIsBlank(t *testing.T) {
 		})
 	}
 }
+
+func TestSourceLabelsMatchSources(t *testing.T) {
+	if len(sourceLabels) != len(gittool.Sources) {
+		t.Fatalf("sourceLabels has %d entries, gittool.Sources has %d", len(sourceLabels), len(gittool.Sources))
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	aitool "github.com/ledzpl/pcl/internal/ai"
+	"github.com/ledzpl/pcl/internal/config"
+	jira "github.com/ledzpl/pcl/internal/jira"
+
+	"github.com/manifoldco/promptui"
+)
+
+// epicIssueType은 -epic에서 찾는 이슈 타입 이름이다. 대소문자는 구분하지 않는다.
+const epicIssueType = "Epic"
+
+// splitItem은 나눠서 만들 이슈 하나다. dependsOn은 먼저 만들어야 하는 splitItem의 번호다.
+type splitItem struct {
+	payload   *jira.IssuePayload
+	epic      bool
+	dependsOn []int
+}
+
+// splitItems는 에픽을 맨 앞에 두고 제안된 이슈를 순서대로 이어 붙인다.
+// dependsOn은 plan.Issues 기준 번호이므로 에픽만큼 밀어 준다.
+func splitItems(plan *aitool.SplitPlan) []splitItem {
+	var items []splitItem
+	offset := 0
+	if plan.Epic != nil {
+		items = append(items, splitItem{payload: plan.Epic, epic: true})
+		offset = 1
+	}
+	for _, issue := range plan.Issues {
+		deps := make([]int, len(issue.DependsOn))
+		for i, d := range issue.DependsOn {
+			deps[i] = d + offset
+		}
+		items = append(items, splitItem{payload: issue.Payload, dependsOn: deps})
+	}
+	return items
+}
+
+// createSplitIssues는 diff를 변경 의도별로 나눈 이슈들을 생성한다. 에픽을 먼저 만들고
+// 나머지 이슈는 에픽의 하위 이슈로, 선행 이슈가 있으면 그 이슈에 막힌 것으로 연결한다.
+func createSplitIssues(g *issueGenerator, flow issueFlow, links []jira.Link) error {
+	var epicType string
+	if flow.epic {
+		t, err := g.epicType()
+		if err != nil {
+			return err
+		}
+		epicType = t
+	}
+
+	plan, err := g.split(epicType, flow.force)
+	var skip *aitool.SkipError
+	if errors.As(err, &skip) {
+		if flow.json && !flow.interactive {
+			return printJSON([]issueOutput{{Skipped: true, Reason: skip.Reason}})
+		}
+		explainSkip(skip)
+		if !flow.interactive {
+			fmt.Println("그래도 이슈를 만들려면 -force를 지정하세요.")
+			return nil
+		}
+		if !confirm("그래도 이슈를 만들까요") {
+			return nil
+		}
+		plan, err = g.split(epicType, true)
+	}
+	if err != nil {
+		return err
+	}
+
+	items := splitItems(plan)
+	selected := make([]bool, len(items))
+	for i := range selected {
+		selected[i] = true
+	}
+	if flow.review {
+		fmt.Printf("\n%s\n", renderSplitPlan(items))
+		p := promptui.Prompt{Label: "생성할 이슈 번호 (예: 1,3, 비우면 전체)", Validate: func(s string) error {
+			_, err := parseSelection(s, len(items))
+			return err
+		}}
+		input, err := p.Run()
+		if err != nil {
+			return errCanceled
+		}
+		if selected, err = parseSelection(input, len(items)); err != nil {
+			return err
+		}
+		warnUnselectedDependencies(items, selected)
+	}
+
+	if flow.dryRun {
+		var payloads []*jira.IssuePayload
+		for i, it := range items {
+			if selected[i] {
+				payloads = append(payloads, it.payload)
+			}
+		}
+		data, err := json.MarshalIndent(payloads, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	outputs, err := g.createSplit(items, selected, links)
+	if len(outputs) > 0 {
+		var keys []string
+		for _, o := range outputs {
+			keys = append(keys, o.Key)
+		}
+		var sprint string
+		if flow.fields.sprint || g.cfg.JiraActiveSprint {
+			sprint = addToActiveSprint(g.cfg, g.jira, keys...)
+		}
+		for i := range outputs {
+			outputs[i].Sprint = sprint
+			applyConfiguredTransition(g.cfg, g.jira, config.ActionIssue, outputs[i].Key)
+		}
+		if flow.json {
+			if jsonErr := printJSON(outputs); jsonErr != nil && err == nil {
+				err = jsonErr
+			}
+		} else {
+			for _, o := range outputs {
+				fmt.Printf("%s %s\n", o.Key, o.URL)
+				for _, l := range o.Links {
+					fmt.Printf("  연결: %s\n", l)
+				}
+			}
+			if sprint != "" {
+				fmt.Printf("스프린트: %s\n", sprint)
+			}
+		}
+	}
+	return err
+}
+
+// epicType은 프로젝트에서 에픽 이슈 타입의 실제 이름을 찾는다.
+func (g *issueGenerator) epicType() (string, error) {
+	if g.meta == nil {
+		return "", fmt.Errorf("프로젝트 메타데이터가 없어 %s 프로젝트의 에픽 타입을 확인할 수 없습니다", g.cfg.JiraProject)
+	}
+	for _, t := range g.meta.IssueTypes {
+		if !t.Subtask && strings.EqualFold(t.Name, epicIssueType) {
+			return t.Name, nil
+		}
+	}
+	return "", fmt.Errorf("%s 프로젝트에 %s 이슈 타입이 없습니다 (사용 가능: %s)", g.cfg.JiraProject, epicIssueType, strings.Join(g.meta.TypeNames(), ", "))
+}
+
+// split은 diff를 나눈 이슈 제안을 받아 각 페이로드에 담당자와 필드 기본값을 채운다.
+// 모델이 이슈를 만들지 않기로 하면 *aitool.SkipError를 반환한다.
+func (g *issueGenerator) split(epicType string, force bool) (*aitool.SplitPlan, error) {
+	s := startSpinner("Jira 이슈 나누는 중... ", "")
+	s.FinalMSG = ""
+
+	plan, err := aitool.SplitIssues(context.Background(), g.provider, g.diff, aitool.SplitOptions{
+		AccountID:  g.user.ID(),
+		Project:    g.cfg.JiraProject,
+		EpicType:   epicType,
+		MaxRepairs: maxRepairs(g.cfg),
+		Force:      force,
+		Meta:       g.meta,
+		Labels:     g.labels,
+	})
+	stopSpinner(s)
+
+	var skip *aitool.SkipError
+	if errors.As(err, &skip) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to split diff: %w", err)
+	}
+	if plan.Epic != nil {
+		g.finish(plan.Epic)
+	}
+	for _, issue := range plan.Issues {
+		g.finish(issue.Payload)
+	}
+	return plan, nil
+}
+
+// createSplit은 선택한 이슈를 순서대로 만들고 연결한다. 중간에 실패하면 그때까지 만든 이슈의 결과와 오류를 함께 반환한다.
+// 에픽이 없으면 선행 이슈가 없는 이슈를 처음 만든 이슈와 Relates로 묶고, -link는 모든 이슈에 건다.
+// 에픽이 있으면 -link는 에픽에만 건다.
+func (g *issueGenerator) createSplit(items []splitItem, selected []bool, links []jira.Link) ([]issueOutput, error) {
+	ctx := context.Background()
+	keys := make([]string, len(items))
+	var epicKey, firstKey string
+	var outputs []issueOutput
+	for i, it := range items {
+		if !selected[i] {
+			continue
+		}
+		if !it.epic && epicKey != "" {
+			it.payload.Fields.Parent = &jira.IssueRef{Key: epicKey}
+		}
+
+		s := startSpinner(fmt.Sprintf("Jira 이슈 생성 중 (%s)... ", it.payload.Fields.Summary), "")
+		s.FinalMSG = ""
+		created, err := g.jira.CreateIssue(ctx, it.payload)
+		stopSpinner(s)
+		if err != nil {
+			return outputs, fmt.Errorf("failed to create Jira issue %q: %w", it.payload.Fields.Summary, err)
+		}
+		keys[i] = created.Key
+
+		var issueLinks []jira.Link
+		for _, d := range it.dependsOn {
+			if keys[d] != "" {
+				issueLinks = append(issueLinks, jira.Link{Type: "Blocks", Key: keys[d], Inward: true})
+			}
+		}
+		switch {
+		case it.epic:
+			epicKey = created.Key
+			issueLinks = append(issueLinks, links...)
+		case epicKey == "":
+			if len(issueLinks) == 0 && firstKey != "" {
+				issueLinks = append(issueLinks, jira.Link{Type: "Relates", Key: firstKey})
+			}
+			issueLinks = append(issueLinks, links...)
+		}
+		if firstKey == "" && !it.epic {
+			firstKey = created.Key
+		}
+
+		outputs = append(outputs, issueOutput{
+			ID:    created.ID,
+			Key:   created.Key,
+			Self:  created.Self,
+			URL:   jira.BrowseURL(g.jira.Host(), created.Key),
+			Links: linkIssue(g.jira, created.Key, issueLinks),
+		})
+	}
+	return outputs, nil
+}
+
+// renderSplitPlan은 나눈 이슈를 1부터 번호를 붙여 보여 준다.
+func renderSplitPlan(items []splitItem) string {
+	var b strings.Builder
+	for i, it := range items {
+		f := it.payload.Fields
+		fmt.Fprintf(&b, "%d. [%s] %s\n", i+1, f.IssueType.Name, f.Summary)
+		if len(it.dependsOn) > 0 {
+			deps := make([]string, len(it.dependsOn))
+			for j, d := range it.dependsOn {
+				deps[j] = strconv.Itoa(d + 1)
+			}
+			fmt.Fprintf(&b, "   선행: %s\n", strings.Join(deps, ", "))
+		}
+	}
+	return b.String()
+}
+
+// parseSelection은 "1,3" 같은 1부터 시작하는 번호 목록을 n개짜리 선택 여부로 바꾼다. 비어 있으면 전체를 선택한다.
+func parseSelection(input string, n int) ([]bool, error) {
+	selected := make([]bool, n)
+	if strings.TrimSpace(input) == "" {
+		for i := range selected {
+			selected[i] = true
+		}
+		return selected, nil
+	}
+	count := 0
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		i, err := strconv.Atoi(part)
+		if err != nil || i < 1 || i > n {
+			return nil, fmt.Errorf("1부터 %d 사이의 번호를 쉼표로 구분해 입력하세요: %q", n, part)
+		}
+		selected[i-1] = true
+		count++
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("생성할 이슈 번호를 하나 이상 입력하세요")
+	}
+	return selected, nil
+}
+
+// warnUnselectedDependencies는 선택한 이슈의 선행 이슈가 빠졌으면 알린다. 연결 없이 만든다.
+func warnUnselectedDependencies(items []splitItem, selected []bool) {
+	for i, it := range items {
+		if !selected[i] {
+			continue
+		}
+		for _, d := range it.dependsOn {
+			if !selected[d] {
+				fmt.Fprintf(os.Stderr, "%d번 이슈의 선행 이슈 %d번을 만들지 않아 두 이슈를 연결하지 않습니다.\n", i+1, d+1)
+			}
+		}
+	}
+}
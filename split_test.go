@@ -0,0 +1,62 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	aitool "github.com/ledzpl/pcl/internal/ai"
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+func TestParseSelection(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []bool
+		wantErr bool
+	}{
+		{"empty", " ", []bool{true, true, true}, false},
+		{"some", "1, 3", []bool{true, false, true}, false},
+		{"trailingComma", "2,", []bool{false, true, false}, false},
+		{"outOfRange", "4", nil, true},
+		{"zero", "0", nil, true},
+		{"notNumber", "a", nil, true},
+		{"onlyCommas", ",,", nil, true},
+	}
+
+	for _, tt := range tests {
+		caseData := tt
+		t.Run(caseData.name, func(t *testing.T) {
+			got, err := parseSelection(caseData.input, 3)
+			if (err != nil) != caseData.wantErr {
+				t.Fatalf("parseSelection(%q) error = %v, wantErr %v", caseData.input, err, caseData.wantErr)
+			}
+			if !reflect.DeepEqual(got, caseData.want) {
+				t.Fatalf("parseSelection(%q) = %v, want %v", caseData.input, got, caseData.want)
+			}
+		})
+	}
+}
+
+func TestSplitItems(t *testing.T) {
+	payload := func(summary, issueType string) *jira.IssuePayload {
+		return &jira.IssuePayload{Fields: jira.IssueFields{Summary: summary, IssueType: jira.IssueTypeRef{Name: issueType}}}
+	}
+	plan := &aitool.SplitPlan{
+		Epic: payload("diff 필터 정비", "Epic"),
+		Issues: []aitool.SplitIssue{
+			{Payload: payload("필터 옵션 추가", "Task")},
+			{Payload: payload("README 갱신", "Task"), DependsOn: []int{0}},
+		},
+	}
+
+	items := splitItems(plan)
+	if len(items) != 3 || !items[0].epic || !reflect.DeepEqual(items[2].dependsOn, []int{1}) {
+		t.Fatalf("splitItems() = %+v, want epic first and dependencies shifted by one", items)
+	}
+
+	want := "1. [Epic] diff 필터 정비\n2. [Task] 필터 옵션 추가\n3. [Task] README 갱신\n   선행: 2\n"
+	if got := renderSplitPlan(items); got != want {
+		t.Fatalf("renderSplitPlan() = %q, want %q", got, want)
+	}
+}
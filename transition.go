@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ledzpl/pcl/internal/config"
+	jira "github.com/ledzpl/pcl/internal/jira"
+
+	"github.com/manifoldco/promptui"
+)
+
+// transitionFlow는 이슈 상태 전환 과정의 선택 사항이다.
+type transitionFlow struct {
+	// key는 전환할 이슈 키다. 비어 있으면 브랜치·커밋에서 찾은 키를 쓴다.
+	key string
+	// base는 이슈 키를 찾을 커밋 범위의 기준 브랜치다.
+	base string
+	// to는 적용할 전환 또는 도착 상태 이름이다. 비어 있으면 목록에서 고른다.
+	to string
+	// list가 true면 적용할 수 있는 전환만 출력한다.
+	list bool
+}
+
+// transitionIssue는 이슈에 적용할 수 있는 전환을 보여주거나, 지정하거나 고른 전환을 적용한다.
+func transitionIssue(cfg *config.Config, flow transitionFlow) error {
+	if err := cfg.ValidateForJira(); err != nil {
+		return fmt.Errorf("설정이 올바르지 않습니다: %w", err)
+	}
+
+	key := flow.key
+	if key == "" {
+		key = detectIssueKey(cfg, flow.base)
+		if key == "" {
+			return fmt.Errorf("상태를 바꿀 이슈 키를 찾지 못했습니다. -issue로 지정하세요")
+		}
+		fmt.Printf("브랜치/커밋에서 찾은 %s 이슈입니다.\n", key)
+	}
+
+	client := newJiraClient(cfg)
+	ctx := context.Background()
+	issue, err := client.GetIssue(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Jira issue %s: %w", key, err)
+	}
+	transitions, err := client.Transitions(ctx, issue.Key)
+	if err != nil {
+		return fmt.Errorf("failed to list Jira transitions: %w", err)
+	}
+
+	if flow.list {
+		fmt.Printf("%s: %s (현재 상태: %s)\n", issue.Key, issue.Fields.Summary, issue.Fields.Status.Name)
+		for _, t := range transitions {
+			fmt.Printf("  %s\n", t)
+		}
+		return nil
+	}
+	if len(transitions) == 0 {
+		return fmt.Errorf("%s 이슈에 지금 적용할 수 있는 전환이 없습니다 (현재 상태: %s)", issue.Key, issue.Fields.Status.Name)
+	}
+
+	var t jira.Transition
+	if flow.to != "" {
+		if strings.EqualFold(issue.Fields.Status.Name, flow.to) {
+			fmt.Printf("%s 이슈는 이미 %s 상태입니다.\n", issue.Key, issue.Fields.Status.Name)
+			return nil
+		}
+		var ok bool
+		if t, ok = jira.FindTransition(transitions, flow.to); !ok {
+			return fmt.Errorf("%s 이슈에 %q 전환이 없습니다. 사용할 수 있는 전환: %s", issue.Key, flow.to, transitionNames(transitions))
+		}
+	} else {
+		p := promptui.Select{
+			Label: fmt.Sprintf("%s (현재 상태: %s) 전환 선택", issue.Key, issue.Fields.Status.Name),
+			Items: transitions,
+		}
+		i, _, err := p.Run()
+		if err != nil {
+			return errCanceled
+		}
+		t = transitions[i]
+	}
+
+	if err := client.TransitionIssue(ctx, issue.Key, t.ID); err != nil {
+		return fmt.Errorf("failed to transition Jira issue: %w", err)
+	}
+	fmt.Printf("%s 이슈를 %s 상태로 옮겼습니다.\n", issue.Key, transitionTarget(t))
+	return nil
+}
+
+// applyConfiguredTransition은 jira_transitions에 action의 전환이 설정되어 있으면 key 이슈에 적용한다.
+// action 자체는 이미 끝났으므로 실패해도 중단하지 않고 표준 에러로 알린다.
+func applyConfiguredTransition(cfg *config.Config, client *jira.Client, action, key string) {
+	name := cfg.TransitionFor(action)
+	if name == "" || key == "" {
+		return
+	}
+
+	ctx := context.Background()
+	issue, err := client.GetIssue(ctx, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s 이슈 상태를 확인하지 못했습니다: %v\n", key, err)
+		return
+	}
+	if strings.EqualFold(issue.Fields.Status.Name, name) {
+		return
+	}
+	transitions, err := client.Transitions(ctx, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s 이슈의 전환 목록을 가져오지 못했습니다: %v\n", key, err)
+		return
+	}
+	t, ok := jira.FindTransition(transitions, name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "%s 이슈(현재 상태: %s)에 %q 전환이 없어 상태를 바꾸지 않았습니다. 사용할 수 있는 전환: %s\n", key, issue.Fields.Status.Name, name, transitionNames(transitions))
+		return
+	}
+	if err := client.TransitionIssue(ctx, key, t.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "%s 이슈를 %s 상태로 옮기지 못했습니다: %v\n", key, transitionTarget(t), err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s 이슈를 %s 상태로 옮겼습니다.\n", key, transitionTarget(t))
+}
+
+// transitionTarget은 전환의 도착 상태 이름이다. 응답에 없으면 전환 이름이다.
+func transitionTarget(t jira.Transition) string {
+	if t.To.Name != "" {
+		return t.To.Name
+	}
+	return t.Name
+}
+
+func transitionNames(transitions []jira.Transition) string {
+	if len(transitions) == 0 {
+		return "(없음)"
+	}
+	names := make([]string, len(transitions))
+	for i, t := range transitions {
+		names[i] = t.String()
+	}
+	return strings.Join(names, ", ")
+}
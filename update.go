@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ledzpl/pcl/internal/adf"
+	aitool "github.com/ledzpl/pcl/internal/ai"
+	"github.com/ledzpl/pcl/internal/config"
+	gittool "github.com/ledzpl/pcl/internal/git"
+	jira "github.com/ledzpl/pcl/internal/jira"
+)
+
+// updateFlow는 기존 이슈 갱신 과정의 선택 사항이다.
+type updateFlow struct {
+	// key는 갱신할 이슈 키다. 비어 있으면 브랜치·커밋에서 찾은 키를 쓴다.
+	key string
+	// base는 이슈 키를 찾을 커밋 범위의 기준 브랜치다.
+	base string
+	// dryRun이 true면 바뀔 내용만 보여주고 이슈는 수정하지 않는다.
+	dryRun bool
+	// yes가 true면 확인 없이 수정한다.
+	yes bool
+	// force가 true면 현재 설명에 pcl이 다시 쓸 수 없는 노드가 있어도 갱신한다.
+	force bool
+	// interactive가 true면 force 대신 사라질 노드를 보여주고 계속할지 묻는다.
+	interactive bool
+}
+
+// updateIssue는 기존 이슈의 설명과 patch를 합친 새 설명을 생성하고, 바뀌는 내용을 보여준 뒤 이슈를 수정한다.
+func updateIssue(cfg *config.Config, patch *gittool.Patch, flow updateFlow) error {
+	if err := cfg.ValidateForJira(); err != nil {
+		return fmt.Errorf("설정이 올바르지 않습니다: %w", err)
+	}
+
+	key := flow.key
+	if key == "" {
+		key = detectIssueKey(cfg, flow.base)
+		if key == "" {
+			return fmt.Errorf("갱신할 이슈 키를 찾지 못했습니다. -issue로 지정하세요")
+		}
+		fmt.Printf("브랜치/커밋에서 찾은 %s 이슈를 갱신합니다.\n", key)
+	}
+
+	client := newJiraClient(cfg)
+	s := startSpinner("이슈 조회 중... ", "")
+	s.FinalMSG = ""
+	issue, err := client.GetIssue(context.Background(), key)
+	stopSpinner(s)
+	if err != nil {
+		return fmt.Errorf("failed to fetch Jira issue %s: %w", key, err)
+	}
+
+	if lost := issue.Fields.UnsupportedDescriptionNodes(); len(lost) > 0 {
+		msg := fmt.Sprintf("%s 이슈의 현재 설명에 pcl이 다룰 수 없는 노드(%s)가 있어 갱신하면 그 내용이 사라집니다", issue.Key, strings.Join(lost, ", "))
+		switch {
+		case flow.force:
+			fmt.Fprintf(os.Stderr, "%s.\n", msg)
+		case flow.interactive:
+			fmt.Printf("%s.\n", msg)
+			if !confirm("그래도 갱신할까요") {
+				return errCanceled
+			}
+		default:
+			return fmt.Errorf("%s. 그래도 갱신하려면 -force를 지정하세요", msg)
+		}
+	}
+
+	doc, err := mergeDescription(cfg, patch, issue)
+	if err != nil {
+		return err
+	}
+
+	before, after := issue.Fields.DescriptionText(), client.RenderDescription(doc)
+	preview, err := gittool.DiffText(key+" (현재)", key+" (갱신 후)", before, after)
+	if err != nil {
+		return err
+	}
+	if preview == "" {
+		fmt.Println("설명에 바뀐 내용이 없어 이슈를 수정하지 않았습니다.")
+		return nil
+	}
+	fmt.Printf("\n%s: %s\n%s\n", issue.Key, issue.Fields.Summary, preview)
+
+	if flow.dryRun {
+		return nil
+	}
+	if !flow.yes && !confirm("이 내용으로 이슈 설명을 바꿀까요") {
+		return errCanceled
+	}
+
+	s = startSpinner("Jira 이슈 수정 중... ", "Jira 이슈 수정 완료\n")
+	if err := client.UpdateDescription(context.Background(), issue.Key, doc); err != nil {
+		s.FinalMSG = ""
+		stopSpinner(s)
+		return fmt.Errorf("failed to update Jira issue: %w", err)
+	}
+	stopSpinner(s)
+	applyConfiguredTransition(cfg, client, config.ActionUpdate, issue.Key)
+
+	fmt.Printf("%s %s\n", issue.Key, jira.BrowseURL(client.Host(), issue.Key))
+	return nil
+}
+
+// mergeDescription은 이슈의 현재 설명과 요약한 diff로 새 설명을 생성한다.
+func mergeDescription(cfg *config.Config, patch *gittool.Patch, issue *jira.Issue) (*adf.Node, error) {
+	provider, err := newProvider(cfg, config.ActionIssue)
+	if err != nil {
+		return nil, err
+	}
+
+	s := startSpinner("이슈 설명 갱신 내용 생성 중... ", "")
+	s.FinalMSG = ""
+	defer stopSpinner(s)
+
+	ctx := context.Background()
+	diff, err := aitool.Condense(ctx, provider, patch, chunkOptions(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize diff: %w", err)
+	}
+
+	doc, err := aitool.MergeDescription(ctx, provider, issue.Fields.DescriptionSource(), diff, aitool.UpdateOptions{
+		Key:        issue.Key,
+		MaxRepairs: maxRepairs(cfg),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge issue description: %w", err)
+	}
+	return doc, nil
+}